@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/simulation"
+)
+
+// bloops-cli simulate -matches 5000 -players 4 -format csv > report.csv
+func simulate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	matches := fs.Int("matches", 1000, "number of matches")
+	players := fs.Int("players", 4, "number of players with the same model, ignored with -models")
+	rounds := fs.Int("rounds", 3, "number of rounds")
+	roundTime := fs.Int("time", 30, "round time in seconds")
+	scoring := fs.String("scoring", "seconds", "scoring: seconds, flat, tiers, words")
+	bloops := fs.Bool("bloops", true, "bloopses drop during the turns")
+	seed := fs.Int64("seed", 0, "seed of the first match, random if zero")
+	format := fs.String("format", "table", "output format: table or csv")
+	models := fs.String("models", "", "json file with the list of player models")
+	startDelay := fs.Float64("start", 3, "seconds to press start")
+	reaction := fs.Float64("reaction", 18, "mean seconds to name the words")
+	spread := fs.Float64("spread", 6, "standard deviation of the reaction time")
+	completion := fs.Float64("completion", 0.8, "probability to finish the usual turn")
+	bloopsCompletion := fs.Float64("bloops-completion", 0.65, "probability to finish the turn with a bloops")
+	words := fs.Float64("words", 0.4, "words typed per second")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	kind, err := simulation.ParseScoring(*scoring)
+	if err != nil {
+		return fmt.Errorf("scoring: %w", err)
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	config := simulation.Config{
+		Matches:   *matches,
+		RoundsNum: *rounds,
+		RoundTime: *roundTime,
+		Scoring:   kind,
+		Bloops:    *bloops,
+		Seed:      *seed,
+	}
+
+	if *models != "" {
+		f, err := os.Open(*models)
+		if err != nil {
+			return fmt.Errorf("open models: %w", err)
+		}
+		defer f.Close()
+
+		if err := json.NewDecoder(f).Decode(&config.Players); err != nil {
+			return fmt.Errorf("decode models: %w", err)
+		}
+	} else {
+		for i := 0; i < *players; i++ {
+			config.Players = append(config.Players, simulation.PlayerModel{
+				Name:             "Игрок " + strconv.Itoa(i+1),
+				StartDelay:       *startDelay,
+				ReactionTime:     *reaction,
+				ReactionSpread:   *spread,
+				Completion:       *completion,
+				BloopsCompletion: *bloopsCompletion,
+				WordsPerSecond:   *words,
+			})
+		}
+	}
+
+	report, err := simulation.Run(ctx, config)
+	if err != nil {
+		return fmt.Errorf("simulation run: %w", err)
+	}
+
+	switch *format {
+	case "csv":
+		return report.WriteCSV(os.Stdout)
+	case "table":
+		return report.WriteTable(os.Stdout)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
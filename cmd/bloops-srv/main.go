@@ -13,13 +13,20 @@ import (
 
 	"github.com/bloops-games/bloops/internal/bloopsbot"
 	"github.com/bloops-games/bloops/internal/database"
+	achievementDb "github.com/bloops-games/bloops/internal/database/achievement/database"
+	builderStateDb "github.com/bloops-games/bloops/internal/database/builderstate/database"
+	feedbackDb "github.com/bloops-games/bloops/internal/database/feedback/database"
+	guestDb "github.com/bloops-games/bloops/internal/database/guest/database"
 	stateDb "github.com/bloops-games/bloops/internal/database/matchstate/database"
+	moderationDb "github.com/bloops-games/bloops/internal/database/moderation/database"
+	presetDb "github.com/bloops-games/bloops/internal/database/preset/database"
 	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
 	userdb "github.com/bloops-games/bloops/internal/database/user/database"
+	webhookDb "github.com/bloops-games/bloops/internal/database/webhook/database"
 	"github.com/bloops-games/bloops/internal/logging"
 	"github.com/bloops-games/bloops/internal/server"
 	"github.com/bloops-games/bloops/internal/shutdown"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/bloops-games/bloops/internal/webhook"
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -58,7 +65,7 @@ func realMain(ctx context.Context, config bloopsbot.Config, done func()) error {
 		)
 	}
 
-	tg, err := tgbotapi.NewBotAPI(config.BotToken)
+	tg, err := bloopsbot.NewBotAPI(&config)
 	if err != nil {
 		return fmt.Errorf("bot api: %w", err)
 	}
@@ -89,8 +96,24 @@ func realMain(ctx context.Context, config bloopsbot.Config, done func()) error {
 		return fmt.Errorf("server.New: %w", err)
 	}
 
+	manager := bloopsbot.NewManager(
+		tg,
+		&config,
+		userdb.New(db, userCache),
+		statDb.New(db, statCache),
+		stateDb.New(db),
+		builderStateDb.New(db),
+		achievementDb.New(db),
+		moderationDb.New(db),
+		feedbackDb.New(db),
+		presetDb.New(db),
+		guestDb.New(db),
+		webhook.NewDispatcher(config.Webhook, webhookDb.New(db)),
+	)
+
 	mux := http.NewServeMux()
 	mux.Handle("/health", server.HandleHealth(ctx))
+	mux.Handle("/tv/", manager.HandlePresenter(ctx))
 
 	go func() {
 		if err := srv.ServeHTTP(ctx, &http.Server{Handler: mux}); err != nil {
@@ -106,7 +129,6 @@ func realMain(ctx context.Context, config bloopsbot.Config, done func()) error {
 		}
 	}()
 
-	manager := bloopsbot.NewManager(tg, &config, userdb.New(db, userCache), statDb.New(db, statCache), stateDb.New(db))
 	if err := manager.Run(ctx); err != nil {
 		return fmt.Errorf("run: %w", err)
 	}
@@ -15,6 +15,7 @@ require (
 	github.com/valyala/fastrand v1.0.0
 	go.etcd.io/bbolt v1.3.5
 	go.uber.org/zap v1.16.0
+	golang.org/x/image v0.0.0-20211028202545-6944b10bf410
 	golang.org/x/sync v0.0.0-20201020160332-67f06af15bc9
 	gopkg.in/yaml.v2 v2.3.0 // indirect
 )
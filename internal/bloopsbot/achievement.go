@@ -0,0 +1,86 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/achievement"
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	achievementDb "github.com/bloops-games/bloops/internal/database/achievement/database"
+	achievementModel "github.com/bloops-games/bloops/internal/database/achievement/model"
+	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
+	"github.com/bloops-games/bloops/internal/logging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// evaluating achievements of the players against their stat history, new ones are stored and their announcements returned
+func (m *manager) unlockAchievements(session *match.Session) ([]tgbotapi.MessageConfig, error) {
+	var announcements []tgbotapi.MessageConfig
+	evaluated := map[int64]struct{}{}
+	for _, player := range session.Players {
+		if player.Offline {
+			continue
+		}
+
+		if _, ok := evaluated[player.UserID]; ok {
+			continue
+		}
+
+		evaluated[player.UserID] = struct{}{}
+
+		history, err := m.statDB.FetchByuserID(player.UserID)
+		if err != nil {
+			if errors.Is(err, statDb.ErrNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("fetch stat by userID: %w", err)
+		}
+
+		unlocked, err := m.fetchAchievements(player.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("fetch achievements: %w", err)
+		}
+
+		kinds := make(map[string]struct{}, len(unlocked))
+		for _, a := range unlocked {
+			kinds[a.Kind] = struct{}{}
+		}
+
+		for _, definition := range achievement.Evaluate(history, kinds) {
+			if err := m.achievementDB.Add(achievementModel.NewAchievement(player.UserID, definition.Kind)); err != nil {
+				return nil, fmt.Errorf("achievement db add: %w", err)
+			}
+
+			msg := tgbotapi.NewMessage(player.ChatID, renderAchievementUnlocked(definition))
+			msg.ParseMode = tgbotapi.ModeMarkdown
+			announcements = append(announcements, msg)
+		}
+	}
+
+	return announcements, nil
+}
+
+// announceAchievements the announcement is best effort, the unlock is already stored
+func (m *manager) announceAchievements(ctx context.Context, announcements []tgbotapi.MessageConfig) {
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.achievements")
+	for _, msg := range announcements {
+		if _, err := m.tg.Send(msg); err != nil {
+			logger.Errorf("send achievement to %d: %v", msg.ChatID, err)
+		}
+	}
+}
+
+func (m *manager) fetchAchievements(userID int64) ([]achievementModel.Achievement, error) {
+	list, err := m.achievementDB.FetchByUserID(userID)
+	if err != nil {
+		if errors.Is(err, achievementDb.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("achievement db fetch: %w", err)
+	}
+
+	return list, nil
+}
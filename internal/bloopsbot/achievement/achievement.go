@@ -0,0 +1,178 @@
+package achievement
+
+import (
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	statModel "github.com/bloops-games/bloops/internal/database/stat/model"
+	"github.com/enescakir/emoji"
+)
+
+const (
+	weekGamesNum    = 5
+	bigWinPoints    = 60
+	veteranGamesNum = 10
+	championWinsNum = 10
+	bloopsMasterNum = 10
+)
+
+// Definition declarative achievement, Check is called with the whole stat history of the user
+type Definition struct {
+	Kind        string
+	Icon        string
+	Title       string
+	Description string
+	Check       func(history []statModel.Stat) bool
+}
+
+var Definitions = []Definition{
+	{
+		Kind:        "first_game",
+		Icon:        emoji.VideoGame.String(),
+		Title:       "Первая игра",
+		Description: "Сыграть первую игру",
+		Check: func(history []statModel.Stat) bool {
+			return len(history) > 0
+		},
+	},
+	{
+		Kind:        "veteran",
+		Icon:        emoji.Joystick.String(),
+		Title:       "Ветеран",
+		Description: "Сыграть 10 игр",
+		Check: func(history []statModel.Stat) bool {
+			return len(history) >= veteranGamesNum
+		},
+	},
+	{
+		Kind:        "first_win",
+		Icon:        emoji.Star.String(),
+		Title:       "Первая победа",
+		Description: "Победить в игре",
+		Check: func(history []statModel.Stat) bool {
+			return wins(history) > 0
+		},
+	},
+	{
+		Kind:        "champion",
+		Icon:        emoji.Trophy.String(),
+		Title:       "Чемпион",
+		Description: "Победить в 10 играх",
+		Check: func(history []statModel.Stat) bool {
+			return wins(history) >= championWinsNum
+		},
+	},
+	{
+		Kind:        "big_win",
+		Icon:        emoji.HundredPoints.String(),
+		Title:       "Разгром",
+		Description: "Победить, набрав 60 и больше очков",
+		Check: func(history []statModel.Stat) bool {
+			for _, stat := range history {
+				if stat.Conclusion == statModel.StatusFavorite && stat.SumPoints >= bigWinPoints {
+					return true
+				}
+			}
+
+			return false
+		},
+	},
+	{
+		Kind:        "bloops_master",
+		Icon:        emoji.GemStone.String(),
+		Title:       "Мастер блюпсов",
+		Description: "Выполнить 10 блюпсов",
+		Check: func(history []statModel.Stat) bool {
+			var n int
+			for _, stat := range history {
+				n += stat.CompletedBloops
+			}
+
+			return n >= bloopsMasterNum
+		},
+	},
+	{
+		Kind:        "bloops_collector",
+		Icon:        emoji.Crown.String(),
+		Title:       "Коллекционер",
+		Description: "Открыть все блюпсы",
+		Check: func(history []statModel.Stat) bool {
+			opened := map[string]struct{}{}
+			for _, stat := range history {
+				for _, name := range stat.Bloops {
+					if _, ok := resource.BloopsKeys[name]; ok {
+						opened[name] = struct{}{}
+					}
+				}
+			}
+
+			return len(opened) == len(resource.BloopsKeys)
+		},
+	},
+	{
+		Kind:        "party_week",
+		Icon:        emoji.PartyPopper.String(),
+		Title:       "Неделя вечеринок",
+		Description: "Сыграть 5 игр за неделю",
+		Check: func(history []statModel.Stat) bool {
+			return maxGamesWithin(history, 7*24*time.Hour) >= weekGamesNum
+		},
+	},
+}
+
+// Evaluate returns definitions that are fulfilled by the history and not unlocked yet
+func Evaluate(history []statModel.Stat, unlocked map[string]struct{}) []Definition {
+	var definitions []Definition
+	for _, definition := range Definitions {
+		if _, ok := unlocked[definition.Kind]; ok {
+			continue
+		}
+
+		if definition.Check(history) {
+			definitions = append(definitions, definition)
+		}
+	}
+
+	return definitions
+}
+
+func Find(kind string) (Definition, bool) {
+	for _, definition := range Definitions {
+		if definition.Kind == kind {
+			return definition, true
+		}
+	}
+
+	return Definition{}, false
+}
+
+func wins(history []statModel.Stat) int {
+	var n int
+	for _, stat := range history {
+		if stat.Conclusion == statModel.StatusFavorite {
+			n++
+		}
+	}
+
+	return n
+}
+
+// the largest number of games played within the sliding window
+func maxGamesWithin(history []statModel.Stat, window time.Duration) int {
+	var max int
+	for _, stat := range history {
+		var n int
+		for _, other := range history {
+			diff := other.CreatedAt.Sub(stat.CreatedAt)
+			if diff >= 0 && diff < window {
+				n++
+			}
+		}
+
+		if n > max {
+			max = n
+		}
+	}
+
+	return max
+}
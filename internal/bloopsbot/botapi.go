@@ -0,0 +1,26 @@
+package bloopsbot
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bloops-games/bloops/internal/httputil"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// NewBotAPI the telegram client, if BotAPIEndpoint is set the requests go there instead of api.telegram.org
+func NewBotAPI(config *Config) (*tgbotapi.BotAPI, error) {
+	if config.BotAPIEndpoint == "" {
+		return tgbotapi.NewBotAPI(config.BotToken)
+	}
+
+	endpoint, err := url.Parse(config.BotAPIEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse bot api endpoint: %w", err)
+	}
+
+	client := httputil.NewClient(httputil.NewEndpointRoundTripper(endpoint, http.DefaultTransport))
+
+	return tgbotapi.NewBotAPIWithClient(config.BotToken, client)
+}
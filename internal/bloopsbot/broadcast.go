@@ -0,0 +1,320 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/logging"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	broadcastProgressInterval = 3 * time.Second
+	broadcastConfirmData      = "broadcast:confirm"
+	broadcastCancelData       = "broadcast:cancel"
+	broadcastStopData         = "broadcast:stop"
+)
+
+type broadcast struct {
+	text   string
+	cancel func()
+
+	mtx       sync.RWMutex
+	total     int
+	sent      int
+	skipped   int
+	blocked   int
+	failed    int
+	stopped   bool
+	completed bool
+	// the users could not be fetched, nothing was sent
+	aborted bool
+}
+
+func (b *broadcast) inc(counter *int) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	*counter++
+}
+
+func (b *broadcast) render() string {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	switch {
+	case b.aborted:
+		_, _ = fmt.Fprintf(buf, "%s *Рассылка прервана*\n\nНе удалось получить список пользователей", emoji.CrossMark.String())
+		return buf.String()
+	case b.stopped:
+		_, _ = fmt.Fprintf(buf, "%s *Рассылка остановлена*\n\n", emoji.StopSign.String())
+	case b.completed:
+		_, _ = fmt.Fprintf(buf, "%s *Рассылка завершена*\n\n", emoji.ChequeredFlag.String())
+	default:
+		_, _ = fmt.Fprintf(buf, "%s *Рассылка идет*\n\n", emoji.Loudspeaker.String())
+	}
+
+	_, _ = fmt.Fprintf(buf, "Отправлено: %d/%d\n", b.sent, b.total)
+	_, _ = fmt.Fprintf(buf, "Пропущено: %d\n", b.skipped)
+	_, _ = fmt.Fprintf(buf, "Заблокировали бота: %d\n", b.blocked)
+	_, _ = fmt.Fprintf(buf, "Ошибок: %d", b.failed)
+
+	return buf.String()
+}
+
+func (m *manager) activeBroadcast() (*broadcast, bool) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.broadcast, m.broadcast != nil
+}
+
+func (m *manager) handleBroadcastCommand(u userModel.User, chatID int64) error {
+	if _, ok := m.activeBroadcast(); ok {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBroadcastRunningMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBroadcastMsg)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(text string) error {
+		m.deleteCommandCbHandler(u.ID)
+
+		// the preview is sent with the same markup the users will get
+		preview := tgbotapi.NewMessage(chatID, text)
+		preview.ParseMode = tgbotapi.ModeMarkdown
+		if _, err := m.tg.Send(preview); err != nil {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBroadcastMarkupErrMsg)); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		msg := tgbotapi.NewMessage(chatID, resource.TextBroadcastPreviewMsg)
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(resource.TextBroadcastConfirm, broadcastConfirmData),
+			tgbotapi.NewInlineKeyboardButtonData(resource.TextBroadcastCancel, broadcastCancelData),
+		))
+		output, err := m.tg.Send(msg)
+		if err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+			return m.handleBroadcastConfirmation(query, chatID, output.MessageID, text)
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (m *manager) handleBroadcastConfirmation(query *tgbotapi.CallbackQuery, chatID int64, messageID int, text string) error {
+	if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	switch query.Data {
+	case broadcastCancelData:
+		m.deleteQueryCbHandler(chatID, messageID)
+		if _, err := m.tg.Send(tgbotapi.NewEditMessageText(chatID, messageID, resource.TextBroadcastCancelledMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+	case broadcastConfirmData:
+		ctx, cancel := context.WithCancel(m.ctxSess)
+		b := &broadcast{text: text, cancel: cancel}
+
+		m.mtx.Lock()
+		if m.broadcast != nil {
+			m.mtx.Unlock()
+			cancel()
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBroadcastRunningMsg)); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+		m.broadcast = b
+		m.mtx.Unlock()
+
+		// the same message becomes the progress report with the stop button
+		m.registerQueryCbHandler(chatID, messageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+			if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+				return fmt.Errorf("send answer msg: %w", err)
+			}
+
+			if query.Data == broadcastStopData {
+				b.cancel()
+			}
+
+			return nil
+		})
+
+		go m.runBroadcast(ctx, b, chatID, messageID)
+	}
+
+	return nil
+}
+
+// sending the message to all active users with the rate limit and reporting the progress to the admin
+func (m *manager) runBroadcast(ctx context.Context, b *broadcast, chatID int64, messageID int) {
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.runBroadcast")
+	defer func() {
+		b.cancel()
+		m.deleteQueryCbHandler(chatID, messageID)
+		m.mtx.Lock()
+		m.broadcast = nil
+		m.mtx.Unlock()
+	}()
+
+	users, err := m.userDB.FetchAll()
+	if err != nil {
+		logger.Errorf("fetch all users: %v", err)
+		b.mtx.Lock()
+		b.aborted = true
+		b.mtx.Unlock()
+		m.sendBroadcastProgress(b, chatID, messageID)
+		return
+	}
+
+	b.mtx.Lock()
+	b.total = len(users)
+	b.mtx.Unlock()
+
+	m.sendBroadcastProgress(b, chatID, messageID)
+
+	rate := m.config.BroadcastRate
+	if rate <= 0 {
+		rate = 1
+	}
+
+	limiter := time.NewTicker(time.Second / time.Duration(rate))
+	defer limiter.Stop()
+	progress := time.NewTicker(broadcastProgressInterval)
+	defer progress.Stop()
+
+UsersLoop:
+	for _, u := range users {
+		// the expired timed ban keeps the banned status until the user comes back
+		if u.IsBanned(time.Now()) || u.BlockedBot {
+			b.inc(&b.skipped)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			break UsersLoop
+		case <-limiter.C:
+		}
+
+		switch err := m.sendBroadcastMsg(ctx, u.ID, b.text); {
+		case err == nil:
+			b.inc(&b.sent)
+		case isBotBlocked(err):
+			b.inc(&b.blocked)
+			if err := m.markBotBlocked(u.ID); err != nil {
+				logger.Errorf("mark bot blocked: %v", err)
+			}
+		default:
+			b.inc(&b.failed)
+			logger.Errorf("send broadcast msg to %d: %v", u.ID, err)
+		}
+
+		select {
+		case <-progress.C:
+			m.sendBroadcastProgress(b, chatID, messageID)
+		default:
+		}
+	}
+
+	b.mtx.Lock()
+	b.stopped = ctx.Err() != nil
+	b.completed = !b.stopped
+	b.mtx.Unlock()
+
+	m.sendBroadcastProgress(b, chatID, messageID)
+}
+
+// telegram asks to slow down with retry_after, the message is sent again once after waiting
+func (m *manager) sendBroadcastMsg(ctx context.Context, userID int64, text string) error {
+	msg := tgbotapi.NewMessage(userID, text)
+	msg.ParseMode = tgbotapi.ModeMarkdown
+
+	_, err := m.tg.Send(msg)
+	var tgErr tgbotapi.Error
+	if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+		timer := time.NewTimer(time.Duration(tgErr.RetryAfter) * time.Second)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		_, err = m.tg.Send(msg)
+	}
+
+	return err
+}
+
+func (m *manager) sendBroadcastProgress(b *broadcast, chatID int64, messageID int) {
+	msg := tgbotapi.NewEditMessageText(chatID, messageID, b.render())
+	msg.ParseMode = tgbotapi.ModeMarkdown
+
+	b.mtx.RLock()
+	if !b.stopped && !b.completed && !b.aborted {
+		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(resource.TextBroadcastStop, broadcastStopData),
+		))
+		msg.ReplyMarkup = &markup
+	}
+	b.mtx.RUnlock()
+
+	if _, err := m.tg.Send(msg); err != nil {
+		logging.DefaultLogger().Named("bloopsbot.manager.sendBroadcastProgress").Errorf("send msg: %v", err)
+	}
+}
+
+// markBotBlocked the user is fetched again, the ban could be changed while the broadcast was running
+func (m *manager) markBotBlocked(userID int64) error {
+	u, err := m.userDB.Fetch(userID)
+	if err != nil {
+		return fmt.Errorf("userdb fetch: %w", err)
+	}
+
+	u.BlockedBot = true
+	if err := m.userDB.Store(u); err != nil {
+		return fmt.Errorf("userdb store: %w", err)
+	}
+
+	return nil
+}
+
+func isBotBlocked(err error) bool {
+	var tgErr tgbotapi.Error
+	if !errors.As(err, &tgErr) {
+		return false
+	}
+
+	return strings.Contains(tgErr.Message, "bot was blocked by the user") ||
+		strings.Contains(tgErr.Message, "user is deactivated")
+}
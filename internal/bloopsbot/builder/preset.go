@@ -0,0 +1,41 @@
+package builder
+
+import (
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/preset/model"
+)
+
+// Preset the finished settings to be saved under the name, the custom categories are kept
+func (bs *Session) Preset(name string) model.Preset {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+
+	p := model.Preset{
+		UserID:      bs.AuthorID,
+		Name:        name,
+		Categories:  make([]resource.Category, len(bs.Categories)),
+		Letters:     make([]resource.Letter, len(bs.Letters)),
+		RoundsNum:   bs.RoundsNum,
+		RoundTime:   bs.RoundTime,
+		Vote:        bs.Vote,
+		VoteMode:    uint8(bs.VoteMode),
+		VoteTime:    bs.VoteTime,
+		Bloops:      bs.Bloops,
+		Scoring:     uint8(bs.Scoring),
+		HotSeat:     bs.HotSeat,
+		LiveBoard:   bs.LiveBoard,
+		TieBreakers: match.SerializeTieBreakers(bs.TieBreakers),
+		SuddenDeath: bs.SuddenDeath,
+		PlayerNames: make([]string, len(bs.PlayerNames)),
+		CreatedAt:   time.Now(),
+	}
+
+	copy(p.Categories, bs.Categories)
+	copy(p.Letters, bs.Letters)
+	copy(p.PlayerNames, bs.PlayerNames)
+
+	return p
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
 	"github.com/enescakir/emoji"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
@@ -14,6 +15,51 @@ const (
 	maxLargeCellsRow = 3
 )
 
+func (bs *Session) renderInlineMode() tgbotapi.InlineKeyboardMarkup {
+	checked := func(ok bool, text string) string {
+		if ok {
+			return emoji.CheckMarkButton.String() + " " + text
+		}
+
+		return text
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(checked(!bs.HotSeat, resource.TextModeMultiDevice), "false"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(checked(bs.HotSeat, resource.TextModeHotSeat), "true"),
+		),
+	)
+}
+
+// the host always plays and can not be removed
+func (bs *Session) renderInlinePlayers() tgbotapi.InlineKeyboardMarkup {
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(emoji.Crown.String()+" "+resource.TextHotSeatHostName, playerDataPrefix+"-1"),
+	))
+
+	row := tgbotapi.NewInlineKeyboardRow()
+	for i, name := range bs.PlayerNames {
+		if len(row) == maxLargeCellsRow {
+			markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+			row = tgbotapi.NewInlineKeyboardRow()
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			emoji.CrossMark.String()+" "+name,
+			playerDataPrefix+strconv.Itoa(i),
+		))
+	}
+
+	if len(row) > 0 {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+	}
+
+	return markup
+}
+
 func (bs *Session) renderInlineBloops() tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(resource.TextVoteYes, "true"),
@@ -21,13 +67,95 @@ func (bs *Session) renderInlineBloops() tgbotapi.InlineKeyboardMarkup {
 	))
 }
 
-func (bs *Session) renderInlineVote() tgbotapi.InlineKeyboardMarkup {
+func (bs *Session) renderInlineBoard() tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(resource.TextVoteYes, "true"),
 		tgbotapi.NewInlineKeyboardButtonData(resource.TextVoteNo, "false"),
 	))
 }
 
+// renderInlineTie the chosen tie-breakers are numbered in the order they are applied
+func (bs *Session) renderInlineTie() tgbotapi.InlineKeyboardMarkup {
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	for _, breaker := range match.TieBreakers {
+		text := breaker.Title()
+		for i, b := range bs.TieBreakers {
+			if b == breaker {
+				text = strconv.Itoa(i+1) + ". " + text
+			}
+		}
+
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(text, tieDataPrefix+strconv.Itoa(int(breaker))),
+		))
+	}
+
+	text := resource.TextSuddenDeath
+	if bs.SuddenDeath {
+		text = emoji.CheckMarkButton.String() + " " + text
+	}
+
+	markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(text, suddenDeathData),
+	))
+
+	return markup
+}
+
+func (bs *Session) renderInlineVote() tgbotapi.InlineKeyboardMarkup {
+	checked := func(ok bool, text string) string {
+		if ok {
+			return emoji.CheckMarkButton.String() + " " + text
+		}
+
+		return text
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	for _, mode := range match.VoteModes {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				checked(bs.Vote && bs.VoteMode == mode, mode.Title()),
+				voteModeDataPrefix+strconv.Itoa(int(mode)),
+			),
+		))
+	}
+
+	markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(checked(!bs.Vote, resource.TextVoteOff), voteModeDataPrefix+"0"),
+	))
+
+	row := tgbotapi.NewInlineKeyboardRow()
+	for _, n := range resource.VoteTimes {
+		row = append(
+			row,
+			tgbotapi.NewInlineKeyboardButtonData(
+				checked(bs.VoteTime == n, fmt.Sprintf("%s %d", emoji.Stopwatch.String(), n)),
+				voteTimeDataPrefix+strconv.Itoa(n),
+			),
+		)
+	}
+	markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+
+	return markup
+}
+
+func (bs *Session) renderInlineScoring() tgbotapi.InlineKeyboardMarkup {
+	var btn tgbotapi.InlineKeyboardButton
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	for _, kind := range match.ScoringKinds {
+		if kind == bs.Scoring {
+			btn = tgbotapi.NewInlineKeyboardButtonData(emoji.CheckMarkButton.String()+" "+kind.Title(), strconv.Itoa(int(kind)))
+		} else {
+			btn = tgbotapi.NewInlineKeyboardButtonData(kind.Title(), strconv.Itoa(int(kind)))
+		}
+
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(btn))
+	}
+
+	return markup
+}
+
 func (bs *Session) renderInlineLetters() tgbotapi.InlineKeyboardMarkup {
 	var btn tgbotapi.InlineKeyboardButton
 	markup := tgbotapi.NewInlineKeyboardMarkup()
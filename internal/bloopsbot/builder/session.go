@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
 	"github.com/bloops-games/bloops/internal/logging"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
@@ -16,6 +18,19 @@ const (
 	defaultRoundsNum = 1
 	minCategoriesNum = 3
 	defaultRoundTime = 30
+	defaultVoteTime  = 30
+	// hot-seat players besides the host
+	maxHotSeatPlayers    = 12
+	maxHotSeatNameLength = 32
+)
+
+// inline data of the vote stage buttons
+const (
+	voteModeDataPrefix = "vote:"
+	voteTimeDataPrefix = "vote_time:"
+	playerDataPrefix   = "player:"
+	tieDataPrefix      = "tie:"
+	suddenDeathData    = tieDataPrefix + "sudden"
 )
 
 type QueryCallbackHandlerFunc func(query *tgbotapi.CallbackQuery) error
@@ -23,23 +38,20 @@ type QueryCallbackHandlerFunc func(query *tgbotapi.CallbackQuery) error
 type stateKind uint8
 
 const (
-	stateKindCategories stateKind = iota + 1
+	stateKindMode stateKind = iota + 1
+	stateKindPlayers
+	stateKindCategories
 	stateKindRoundsNum
 	stateKindLetters
 	stateKindBloops
 	stateKindVote
-	stateKindDone
+	stateKindScoring
+	stateKindSummary
+	// added after the summary to keep the stages of the saved sessions
+	stateKindBoard
+	stateKindTie
 )
 
-var stages = []stateKind{
-	stateKindCategories,
-	stateKindRoundsNum,
-	stateKindLetters,
-	stateKindBloops,
-	stateKindVote,
-	stateKindDone,
-}
-
 func NewSession(
 	tg *tgbotapi.BotAPI,
 	chatID int64,
@@ -49,21 +61,22 @@ func NewSession(
 	warnFn func(session *Session) error,
 	timeout time.Duration,
 ) (*Session, error) {
-	state := newStateMachine(stages...)
 	s := &Session{
 		tg:              tg,
-		state:           state,
 		messageCh:       make(chan struct{}, 1),
 		ChatID:          chatID,
 		AuthorID:        authorID,
 		AuthorName:      authorName,
 		RoundsNum:       defaultRoundsNum,
 		RoundTime:       defaultRoundTime,
+		Scoring:         match.ScoringKindSeconds,
+		VoteMode:        match.VoteModeAll,
+		VoteTime:        defaultVoteTime,
 		timeout:         timeout,
 		doneFn:          doneFn,
 		warnFn:          warnFn,
+		done:            make(chan struct{}),
 		controlHandlers: map[string]QueryCallbackHandlerFunc{},
-		actionHandlers:  map[stateKind]QueryCallbackHandlerFunc{},
 		CreatedAt:       time.Now(),
 	}
 
@@ -76,16 +89,17 @@ func NewSession(
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	s.stages = s.newStages()
+	kinds := make([]stateKind, len(s.stages))
+	for i, stage := range s.stages {
+		kinds[i] = stage.kind
+	}
+	s.state = newStateMachine(kinds...)
+
 	s.handleControlCb(resource.BuilderInlineNextData, s.clickOnNext)
 	s.handleControlCb(resource.BuilderInlinePrevData, s.clickOnPrev)
 	s.handleControlCb(resource.BuilderInlineDoneData, s.clickOnDone)
 
-	s.handleActionCb(stateKindCategories, s.clickOnCategories)
-	s.handleActionCb(stateKindRoundsNum, s.clickOnRoundsNum)
-	s.handleActionCb(stateKindLetters, s.clickOnLetters)
-	s.handleActionCb(stateKindBloops, s.clickOnBloops)
-	s.handleActionCb(stateKindVote, s.clickOnVote)
-
 	return s, nil
 }
 
@@ -97,22 +111,40 @@ type Session struct {
 	RoundsNum  int
 	RoundTime  int
 	Vote       bool
+	VoteMode   match.VoteMode
+	VoteTime   int
 	Bloops     bool
-	ChatID     int64
-	CreatedAt  time.Time
-
-	tg        *tgbotapi.BotAPI
-	state     *stateMachine
+	Scoring    match.ScoringKind
+	// one phone for all players, the host enters the names of the other players
+	HotSeat     bool
+	PlayerNames []string
+	// one pinned message with the state of the match for every player
+	LiveBoard bool
+	// in the order the author has chosen them
+	TieBreakers []match.TieBreaker
+	SuddenDeath bool
+	ChatID      int64
+	CreatedAt   time.Time
+	// the letters that are the hardest for all players, suggested to be removed, not a setting
+	HardLetters []string
+
+	tg     *tgbotapi.BotAPI
+	stages []stage
+	state  *stateMachine
+	// the author has reached the summary, the next stage is the summary again
+	reviewing bool
+	// the settings are confirmed by the author
+	completed bool
 	messageCh chan struct{}
 	sema      sync.Once
 
 	messageID int
 
 	timeout time.Duration
+	done    chan struct{}
 
 	mtx             sync.RWMutex
 	controlHandlers map[string]QueryCallbackHandlerFunc
-	actionHandlers  map[stateKind]QueryCallbackHandlerFunc
 
 	cancel func()
 	doneFn func(session *Session) error
@@ -156,14 +188,33 @@ func (bs *Session) Execute(upd tgbotapi.Update) error {
 }
 
 func (bs *Session) executeMessageQuery(query *tgbotapi.Message) error {
-	if bs.state.curr() == stateKindCategories {
-		bs.Categories = append(bs.Categories, resource.Category{
-			Text:   query.Text,
-			Status: true,
-		})
-
-		msg := tgbotapi.NewEditMessageReplyMarkup(bs.ChatID, bs.messageID, bs.menuInlineButtons(bs.renderInlineCategories()))
-		if _, err := bs.tg.Send(msg); err != nil {
+	stage, ok := bs.stage(bs.state.curr())
+	if !ok || stage.input == nil {
+		return nil
+	}
+
+	if err := stage.input(query.Text); err != nil {
+		return fmt.Errorf("stage input: %w", err)
+	}
+
+	return nil
+}
+
+func (bs *Session) inputPlayers(text string) error {
+	var added []string
+	for _, name := range strings.Split(text, ",") {
+		if name, ok := bs.addPlayerName(name); ok {
+			added = append(added, name)
+		}
+	}
+
+	msg := tgbotapi.NewEditMessageReplyMarkup(bs.ChatID, bs.messageID, bs.menuInlineButtons(bs.renderInlinePlayers()))
+	if _, err := bs.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	if len(added) > 0 {
+		if _, err := bs.tg.Send(tgbotapi.NewMessage(bs.ChatID, fmt.Sprintf(resource.TextHotSeatAddedPlayer, strings.Join(added, ", ")))); err != nil {
 			return fmt.Errorf("send msg: %w", err)
 		}
 	}
@@ -171,12 +222,22 @@ func (bs *Session) executeMessageQuery(query *tgbotapi.Message) error {
 	return nil
 }
 
-func (bs *Session) handleControlCb(command string, fn QueryCallbackHandlerFunc) {
-	bs.controlHandlers[command] = fn
+func (bs *Session) inputCategory(text string) error {
+	bs.Categories = append(bs.Categories, resource.Category{
+		Text:   text,
+		Status: true,
+	})
+
+	msg := tgbotapi.NewEditMessageReplyMarkup(bs.ChatID, bs.messageID, bs.menuInlineButtons(bs.renderInlineCategories()))
+	if _, err := bs.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
 }
 
-func (bs *Session) handleActionCb(kind stateKind, fn QueryCallbackHandlerFunc) {
-	bs.actionHandlers[kind] = fn
+func (bs *Session) handleControlCb(command string, fn QueryCallbackHandlerFunc) {
+	bs.controlHandlers[command] = fn
 }
 
 func (bs *Session) isControlCmd(queryData string) bool {
@@ -203,13 +264,12 @@ func (bs *Session) executeCbQuery(query *tgbotapi.CallbackQuery) error {
 		return nil
 	}
 
-	kind := bs.state.curr()
-	fn, ok := bs.actionHandlers[kind]
-	if !ok {
+	stage, ok := bs.stage(bs.state.curr())
+	if !ok || stage.action == nil {
 		return fmt.Errorf("action handler not found")
 	}
 
-	if err := fn(query); err != nil {
+	if err := stage.action(query); err != nil {
 		return fmt.Errorf("action handle: %w", err)
 	}
 
@@ -217,77 +277,55 @@ func (bs *Session) executeCbQuery(query *tgbotapi.CallbackQuery) error {
 }
 
 func (bs *Session) loop(ctx context.Context) {
-	logger := logging.FromContext(ctx).Named("builder.loop")
-	defer bs.shutdown(ctx)
+	defer func() {
+		bs.shutdown(ctx)
+		close(bs.done)
+	}()
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-bs.messageCh:
-			switch bs.state.curr() {
-			case stateKindCategories:
-				logger.Infof("Building session, sending categories, author %s", bs.AuthorName)
-				msg := tgbotapi.NewMessage(bs.ChatID, resource.TextChooseCategories)
-				msg.ReplyMarkup = bs.menuInlineButtons(bs.renderInlineCategories())
-				output, err := bs.tg.Send(msg)
-				if err != nil {
-					logger.Errorf("send categories: %v", err)
-				}
-				bs.messageID = output.MessageID
-			case stateKindRoundsNum:
-				logger.Infof("Building session, sending rounds number, author %s", bs.AuthorName)
-				msg := tgbotapi.NewMessage(bs.ChatID, resource.TextChooseRoundsNum)
-				msg.ReplyMarkup = bs.menuInlineButtons(bs.renderRoundsNum())
-				output, err := bs.tg.Send(msg)
-				if err != nil {
-					logger.Errorf("send round num: %v", err)
-				}
-				bs.messageID = output.MessageID
-			case stateKindLetters:
-				logger.Infof("Building session, sending letters, author %s", bs.AuthorName)
-				msg := tgbotapi.NewMessage(bs.ChatID, resource.TextDeleteComplexLetters)
-				msg.ReplyMarkup = bs.menuInlineButtons(bs.renderInlineLetters())
-				output, err := bs.tg.Send(msg)
-				if err != nil {
-					logger.Errorf("send letters: %v", err)
-				}
-				bs.messageID = output.MessageID
-			case stateKindBloops:
-				logger.Infof("Building session, sending bloopses, author %s", bs.AuthorName)
-				msg := tgbotapi.NewMessage(bs.ChatID, resource.TextBloopsAllowed)
-				msg.ReplyMarkup = bs.menuInlineButtons(bs.renderInlineBloops())
-				output, err := bs.tg.Send(msg)
-				if err != nil {
-					logger.Errorf("send letters: %v", err)
-				}
-				bs.messageID = output.MessageID
-			case stateKindVote:
-				logger.Infof("Building session, sending vote, author %s", bs.AuthorName)
-				msg := tgbotapi.NewMessage(bs.ChatID, resource.TextVoteAllowed)
-				msg.ReplyMarkup = bs.menuInlineButtons(bs.renderInlineVote())
-				output, err := bs.tg.Send(msg)
-				if err != nil {
-					logger.Errorf("send vote: %v", err)
-				}
-				bs.messageID = output.MessageID
-			case stateKindDone:
-				logger.Infof("Building session, sending done action, author %s", bs.AuthorName)
-				msg := tgbotapi.NewMessage(bs.ChatID, resource.TextConfigurationDone)
-				msg.ReplyMarkup = bs.menuInlineButtons(tgbotapi.NewInlineKeyboardMarkup())
-				output, err := bs.tg.Send(msg)
-				if err != nil {
-					logger.Errorf("send done: %v", err)
-				}
-				bs.messageID = output.MessageID
+		case _, ok := <-bs.messageCh:
+			if !ok {
+				return
 			}
+
+			bs.sendStage(ctx)
 		}
 	}
 }
 
+// sendStage the message of the current stage, its buttons are handled until the next stage is sent
+func (bs *Session) sendStage(ctx context.Context) {
+	logger := logging.FromContext(ctx).Named("builder.sendStage")
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	stage, ok := bs.stage(bs.state.curr())
+	if !ok {
+		return
+	}
+
+	logger.Infof("Building session, sending stage %d, author %s", stage.kind, bs.AuthorName)
+	text, markup := stage.render()
+	msg := tgbotapi.NewMessage(bs.ChatID, text)
+	if stage.kind != stateKindSummary {
+		// the summary contains the category names written by the author
+		msg.ParseMode = tgbotapi.ModeMarkdown
+	}
+	msg.ReplyMarkup = bs.menuInlineButtons(markup)
+	output, err := bs.tg.Send(msg)
+	if err != nil {
+		logger.Errorf("send stage: %v", err)
+	}
+	bs.messageID = output.MessageID
+}
+
 func (bs *Session) shutdown(ctx context.Context) bool {
 	logger := logging.FromContext(ctx)
 	if time.Since(bs.CreatedAt) <= bs.timeout {
-		if bs.state.state != stateKindDone {
+		if !bs.completed {
 			if _, err := bs.tg.Send(tgbotapi.NewMessage(bs.AuthorID, resource.TextBuilderWarnMsg)); err != nil {
 				logger.Errorf("send msg: %v", err)
 			}
@@ -307,7 +345,7 @@ func (bs *Session) shutdown(ctx context.Context) bool {
 }
 
 func (bs *Session) clickOnPrev(query *tgbotapi.CallbackQuery) error {
-	bs.state.prev()
+	bs.prevStage()
 	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.BuilderInlinePrevText)); err != nil {
 		return fmt.Errorf("send answer msg: %w", err)
 	}
@@ -317,8 +355,20 @@ func (bs *Session) clickOnPrev(query *tgbotapi.CallbackQuery) error {
 }
 
 func (bs *Session) clickOnNext(query *tgbotapi.CallbackQuery) error {
-	bs.state.next()
-	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.BuilderInlineNextText)); err != nil {
+	return bs.forward(query, resource.BuilderInlineNextText)
+}
+
+// forward the current stage is validated before the next one is sent, the invalid setting is shown in the alert
+func (bs *Session) forward(query *tgbotapi.CallbackQuery, answer string) error {
+	if text := bs.nextStage(); text != "" {
+		if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(query.ID, text)); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, answer)); err != nil {
 		return fmt.Errorf("send answer msg: %w", err)
 	}
 	bs.messageCh <- struct{}{}
@@ -326,30 +376,89 @@ func (bs *Session) clickOnNext(query *tgbotapi.CallbackQuery) error {
 	return nil
 }
 
+// clickOnDone all stages are validated, the author returns to the first invalid one
 func (bs *Session) clickOnDone(query *tgbotapi.CallbackQuery) error {
+	stage, text, ok := bs.invalidStage()
+	if ok {
+		if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(query.ID, text)); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		bs.state.seek(stage.kind)
+		bs.messageCh <- struct{}{}
+
+		return nil
+	}
+
 	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.BuilderInlineDoneText)); err != nil {
 		return fmt.Errorf("send answer msg: %w", err)
 	}
 
-	if bs.numCategoriesIncluded() < minCategoriesNum {
-		msg := tgbotapi.NewMessage(bs.ChatID, resource.TextAddLeastCategoryToComplete)
-		if _, err := bs.tg.Send(msg); err != nil {
-			return fmt.Errorf("send msg: %w", err)
-		}
+	bs.completed = true
+	bs.cancel()
 
-		return nil
+	return nil
+}
+
+// clickOnSummary returns to the chosen stage, the next click brings the author back to the summary
+func (bs *Session) clickOnSummary(query *tgbotapi.CallbackQuery) error {
+	n, err := strconv.Atoi(strings.TrimPrefix(query.Data, seekDataPrefix))
+	if err != nil {
+		return fmt.Errorf("strconv: %w", err)
 	}
 
-	if !bs.lettersExist() {
-		msg := tgbotapi.NewMessage(bs.ChatID, resource.TextAddLeastOneLetterToComplete)
-		if _, err := bs.tg.Send(msg); err != nil {
-			return fmt.Errorf("send msg: %w", err)
-		}
+	stage, ok := bs.stage(stateKind(n))
+	if !ok {
+		return fmt.Errorf("stage %d not found", n)
+	}
 
-		return nil
+	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, stage.title)); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
 	}
 
-	bs.cancel()
+	bs.reviewing = true
+	bs.state.seek(stage.kind)
+	bs.messageCh <- struct{}{}
+
+	return nil
+}
+
+func (bs *Session) clickOnMode(query *tgbotapi.CallbackQuery) error {
+	value, err := strconv.ParseBool(query.Data)
+	if err != nil {
+		return fmt.Errorf("strconv: %w", err)
+	}
+
+	title := resource.TextModeMultiDevice
+	if value {
+		title = resource.TextModeHotSeat
+	}
+
+	bs.HotSeat = value
+
+	return bs.forward(query, fmt.Sprintf(resource.TextModeAnswer, title))
+}
+
+func (bs *Session) clickOnPlayers(query *tgbotapi.CallbackQuery) error {
+	idx, err := strconv.Atoi(strings.TrimPrefix(query.Data, playerDataPrefix))
+	if err != nil {
+		return fmt.Errorf("strconv: %w", err)
+	}
+
+	var answer string
+	if idx >= 0 && idx < len(bs.PlayerNames) {
+		answer = fmt.Sprintf(resource.TextHotSeatDeletedPlayer, bs.PlayerNames[idx])
+		bs.PlayerNames = append(bs.PlayerNames[:idx], bs.PlayerNames[idx+1:]...)
+	}
+
+	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, answer)); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	msg := tgbotapi.NewEditMessageReplyMarkup(bs.ChatID, bs.messageID, bs.menuInlineButtons(bs.renderInlinePlayers()))
+	if _, err := bs.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
 
 	return nil
 }
@@ -385,15 +494,9 @@ func (bs *Session) clickOnRoundsNum(query *tgbotapi.CallbackQuery) error {
 		return fmt.Errorf("strconv: %w", err)
 	}
 
-	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, fmt.Sprintf(resource.TextRoundsNumAnswer, n))); err != nil {
-		return fmt.Errorf("send answer msg: %w", err)
-	}
-
 	bs.RoundsNum = n
-	bs.state.next()
-	bs.messageCh <- struct{}{}
 
-	return nil
+	return bs.forward(query, fmt.Sprintf(resource.TextRoundsNumAnswer, n))
 }
 
 func (bs *Session) clickOnLetters(query *tgbotapi.CallbackQuery) error {
@@ -427,34 +530,170 @@ func (bs *Session) clickOnBloops(query *tgbotapi.CallbackQuery) error {
 		return fmt.Errorf("strconv: %w", err)
 	}
 
-	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.BuilderInlineNextText)); err != nil {
-		return fmt.Errorf("send answer msg: %w", err)
-	}
-
 	bs.Bloops = value
-	bs.state.next()
-	bs.messageCh <- struct{}{}
 
-	return nil
+	return bs.forward(query, resource.BuilderInlineNextText)
 }
 
-func (bs *Session) clickOnVote(query *tgbotapi.CallbackQuery) error {
+func (bs *Session) clickOnBoard(query *tgbotapi.CallbackQuery) error {
 	value, err := strconv.ParseBool(query.Data)
 	if err != nil {
 		return fmt.Errorf("strconv: %w", err)
 	}
 
-	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.BuilderInlineNextText)); err != nil {
+	bs.LiveBoard = value
+
+	return bs.forward(query, resource.BuilderInlineNextText)
+}
+
+func (bs *Session) clickOnVote(query *tgbotapi.CallbackQuery) error {
+	var answer string
+	switch {
+	case strings.HasPrefix(query.Data, voteModeDataPrefix):
+		n, err := strconv.Atoi(strings.TrimPrefix(query.Data, voteModeDataPrefix))
+		if err != nil {
+			return fmt.Errorf("strconv: %w", err)
+		}
+
+		bs.Vote = n > 0
+		answer = resource.TextVoteOff
+		if bs.Vote {
+			bs.VoteMode = match.VoteMode(n)
+			answer = fmt.Sprintf(resource.TextVoteModeAnswer, bs.VoteMode.Title())
+		}
+	case strings.HasPrefix(query.Data, voteTimeDataPrefix):
+		n, err := strconv.Atoi(strings.TrimPrefix(query.Data, voteTimeDataPrefix))
+		if err != nil {
+			return fmt.Errorf("strconv: %w", err)
+		}
+
+		bs.VoteTime = n
+		answer = fmt.Sprintf(resource.TextVoteTimeAnswer, n)
+	default:
+		return fmt.Errorf("unknown vote data %s", query.Data)
+	}
+
+	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, answer)); err != nil {
 		return fmt.Errorf("send answer msg: %w", err)
 	}
 
-	bs.Vote = value
-	bs.state.next()
-	bs.messageCh <- struct{}{}
+	msg := tgbotapi.NewEditMessageReplyMarkup(bs.ChatID, bs.messageID, bs.menuInlineButtons(bs.renderInlineVote()))
+	if _, err := bs.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
 
 	return nil
 }
 
+// clickOnTie the tie-breakers are applied in the order of the clicks, the second click removes the tie-breaker
+func (bs *Session) clickOnTie(query *tgbotapi.CallbackQuery) error {
+	var answer string
+	if query.Data == suddenDeathData {
+		bs.SuddenDeath = !bs.SuddenDeath
+		answer = resource.TextSuddenDeath
+	} else {
+		n, err := strconv.Atoi(strings.TrimPrefix(query.Data, tieDataPrefix))
+		if err != nil {
+			return fmt.Errorf("strconv: %w", err)
+		}
+
+		breaker := match.TieBreaker(n)
+		answer = breaker.Title()
+		bs.toggleTieBreaker(breaker)
+	}
+
+	if _, err := bs.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, answer)); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	msg := tgbotapi.NewEditMessageReplyMarkup(bs.ChatID, bs.messageID, bs.menuInlineButtons(bs.renderInlineTie()))
+	if _, err := bs.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (bs *Session) toggleTieBreaker(breaker match.TieBreaker) {
+	for i, b := range bs.TieBreakers {
+		if b == breaker {
+			bs.TieBreakers = append(bs.TieBreakers[:i], bs.TieBreakers[i+1:]...)
+			return
+		}
+	}
+
+	bs.TieBreakers = append(bs.TieBreakers, breaker)
+}
+
+func (bs *Session) clickOnScoring(query *tgbotapi.CallbackQuery) error {
+	n, err := strconv.Atoi(query.Data)
+	if err != nil {
+		return fmt.Errorf("strconv: %w", err)
+	}
+
+	bs.Scoring = match.ScoringKind(n)
+
+	return bs.forward(query, fmt.Sprintf(resource.TextScoringAnswer, bs.Scoring.Title()))
+}
+
+// nextStage the current stage is left only with the valid setting, the returned text explains what is wrong
+// the stages that do not apply to the chosen settings are skipped
+func (bs *Session) nextStage() string {
+	if stage, ok := bs.stage(bs.state.curr()); ok && stage.validate != nil {
+		if text := stage.validate(); text != "" {
+			return text
+		}
+	}
+
+	// the changed setting may turn on the stage that has not been filled yet
+	if bs.reviewing {
+		if stage, _, ok := bs.invalidStage(); ok {
+			bs.state.seek(stage.kind)
+		} else {
+			bs.state.seek(stateKindSummary)
+		}
+
+		return ""
+	}
+
+	for bs.state.next() && bs.skipStage(bs.state.curr()) {
+	}
+
+	return ""
+}
+
+func (bs *Session) prevStage() {
+	for bs.state.prev() && bs.skipStage(bs.state.curr()) {
+	}
+}
+
+func (bs *Session) skipStage(kind stateKind) bool {
+	stage, ok := bs.stage(kind)
+	return ok && stage.skipped()
+}
+
+// addPlayerName the empty names, the duplicates and the names over the limit are skipped
+func (bs *Session) addPlayerName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(bs.PlayerNames) >= maxHotSeatPlayers {
+		return "", false
+	}
+
+	if runes := []rune(name); len(runes) > maxHotSeatNameLength {
+		name = string(runes[:maxHotSeatNameLength])
+	}
+
+	for _, n := range bs.PlayerNames {
+		if strings.EqualFold(n, name) {
+			return "", false
+		}
+	}
+
+	bs.PlayerNames = append(bs.PlayerNames, name)
+
+	return name, true
+}
+
 func (bs *Session) lettersExist() bool {
 	for _, letter := range bs.Letters {
 		if letter.Status {
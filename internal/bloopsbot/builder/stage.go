@@ -0,0 +1,288 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// inline data of the summary buttons
+const seekDataPrefix = "seek:"
+
+// stage one step of the builder, a new setting is added as a stage and the loop stays the same
+type stage struct {
+	kind  stateKind
+	title string
+	// text and inline buttons of the stage message
+	render func() (string, tgbotapi.InlineKeyboardMarkup)
+	// clicks on the stage buttons, the menu buttons are handled by the session
+	action QueryCallbackHandlerFunc
+	// text messages of the author, nil if the stage expects only clicks
+	input func(text string) error
+	// checked before leaving the stage forward, returns the text for the author if the setting is not valid
+	validate func() string
+	// the stage does not apply to the chosen settings
+	skip func() bool
+	// short value of the setting for the summary
+	value func() string
+}
+
+func (bs *Session) newStages() []stage {
+	return []stage{
+		{
+			kind:  stateKindMode,
+			title: resource.TextStageMode,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextChooseMode, bs.renderInlineMode()
+			},
+			action: bs.clickOnMode,
+			value: func() string {
+				if bs.HotSeat {
+					return resource.TextModeHotSeat
+				}
+
+				return resource.TextModeMultiDevice
+			},
+		},
+		{
+			kind:  stateKindPlayers,
+			title: resource.TextStagePlayers,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextHotSeatPlayersMsg, bs.renderInlinePlayers()
+			},
+			action: bs.clickOnPlayers,
+			input:  bs.inputPlayers,
+			validate: func() string {
+				if len(bs.PlayerNames) == 0 {
+					return resource.TextHotSeatAddPlayers
+				}
+
+				return ""
+			},
+			skip: func() bool {
+				return !bs.HotSeat
+			},
+			value: func() string {
+				return strings.Join(bs.PlayerNames, ", ")
+			},
+		},
+		{
+			kind:  stateKindCategories,
+			title: resource.TextStageCategories,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextChooseCategories, bs.renderInlineCategories()
+			},
+			action: bs.clickOnCategories,
+			input:  bs.inputCategory,
+			validate: func() string {
+				if bs.numCategoriesIncluded() < minCategoriesNum {
+					return resource.TextAddLeastCategoryToComplete
+				}
+
+				return ""
+			},
+			value: func() string {
+				names := make([]string, 0, len(bs.Categories))
+				for _, category := range bs.Categories {
+					if category.Status {
+						names = append(names, category.Text)
+					}
+				}
+
+				return strings.Join(names, ", ")
+			},
+		},
+		{
+			kind:  stateKindRoundsNum,
+			title: resource.TextStageRoundsNum,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextChooseRoundsNum, bs.renderRoundsNum()
+			},
+			action: bs.clickOnRoundsNum,
+			value: func() string {
+				return strconv.Itoa(bs.RoundsNum)
+			},
+		},
+		{
+			kind:  stateKindLetters,
+			title: resource.TextStageLetters,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				if len(bs.HardLetters) == 0 {
+					return resource.TextDeleteComplexLetters, bs.renderInlineLetters()
+				}
+
+				text := resource.TextDeleteComplexLetters + "\n\n" +
+					fmt.Sprintf(resource.TextHardLettersHint, strings.Join(bs.HardLetters, " "))
+
+				return text, bs.renderInlineLetters()
+			},
+			action: bs.clickOnLetters,
+			validate: func() string {
+				if !bs.lettersExist() {
+					return resource.TextAddLeastOneLetterToComplete
+				}
+
+				return ""
+			},
+			value: func() string {
+				letters := make([]string, 0, len(bs.Letters))
+				for _, letter := range bs.Letters {
+					if letter.Status {
+						letters = append(letters, letter.Text)
+					}
+				}
+
+				return strings.Join(letters, " ")
+			},
+		},
+		{
+			kind:  stateKindBloops,
+			title: resource.TextStageBloops,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextBloopsAllowed, bs.renderInlineBloops()
+			},
+			action: bs.clickOnBloops,
+			value: func() string {
+				if bs.Bloops {
+					return resource.TextVoteYes
+				}
+
+				return resource.TextVoteNo
+			},
+		},
+		{
+			kind:  stateKindVote,
+			title: resource.TextStageVote,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextVoteAllowed, bs.renderInlineVote()
+			},
+			action: bs.clickOnVote,
+			// the players are sitting at the same table, there is no one to vote from another phone
+			skip: func() bool {
+				return bs.HotSeat
+			},
+			value: func() string {
+				if !bs.Vote {
+					return resource.TextVoteOff
+				}
+
+				return fmt.Sprintf("%s, %s %d", bs.VoteMode.Title(), emoji.Stopwatch.String(), bs.VoteTime)
+			},
+		},
+		{
+			kind:  stateKindBoard,
+			title: resource.TextStageBoard,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextBoardAllowed, bs.renderInlineBoard()
+			},
+			action: bs.clickOnBoard,
+			value: func() string {
+				if bs.LiveBoard {
+					return resource.TextVoteYes
+				}
+
+				return resource.TextVoteNo
+			},
+		},
+		{
+			kind:  stateKindTie,
+			title: resource.TextStageTie,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextChooseTie, bs.renderInlineTie()
+			},
+			action: bs.clickOnTie,
+			value: func() string {
+				return match.TieTitle(bs.TieBreakers, bs.SuddenDeath)
+			},
+		},
+		{
+			kind:  stateKindScoring,
+			title: resource.TextStageScoring,
+			render: func() (string, tgbotapi.InlineKeyboardMarkup) {
+				return resource.TextChooseScoring, bs.renderInlineScoring()
+			},
+			action: bs.clickOnScoring,
+			value: func() string {
+				return bs.Scoring.Title()
+			},
+		},
+		{
+			kind:   stateKindSummary,
+			render: bs.renderSummary,
+			action: bs.clickOnSummary,
+		},
+	}
+}
+
+func (bs *Session) stage(kind stateKind) (stage, bool) {
+	for _, s := range bs.stages {
+		if s.kind == kind {
+			return s, true
+		}
+	}
+
+	return stage{}, false
+}
+
+func (s stage) skipped() bool {
+	return s.skip != nil && s.skip()
+}
+
+// invalidStage the first stage with the setting that does not pass the validation
+func (bs *Session) invalidStage() (stage, string, bool) {
+	for _, s := range bs.stages {
+		if s.validate == nil || s.skipped() {
+			continue
+		}
+
+		if text := s.validate(); text != "" {
+			return s, text, true
+		}
+	}
+
+	return stage{}, "", false
+}
+
+// renderSummary all settings of the game, a click on the setting returns to its stage
+func (bs *Session) renderSummary() (string, tgbotapi.InlineKeyboardMarkup) {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	buf.WriteString(resource.TextBuilderSummaryMsg)
+	buf.WriteString("\n")
+
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	row := tgbotapi.NewInlineKeyboardRow()
+	for _, s := range bs.stages {
+		if s.kind == stateKindSummary || s.skipped() {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(buf, "\n%s: %s", s.title, s.value())
+
+		if len(row) == maxLargeCellsRow {
+			markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+			row = tgbotapi.NewInlineKeyboardRow()
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			emoji.Pencil.String()+" "+s.title,
+			seekDataPrefix+strconv.Itoa(int(s.kind)),
+		))
+	}
+
+	if len(row) > 0 {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+	}
+
+	return buf.String(), markup
+}
@@ -0,0 +1,58 @@
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+)
+
+func TestSessionNextStage(t *testing.T) {
+	t.Parallel()
+
+	bs, err := NewSession(nil, 1, 1, "author", nil, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("new session: %v", err)
+	}
+
+	bs.HotSeat = true
+	if text := bs.nextStage(); text != "" || bs.state.curr() != stateKindPlayers {
+		t.Fatalf("expected players stage, got %d %q", bs.state.curr(), text)
+	}
+
+	if text := bs.nextStage(); text != resource.TextHotSeatAddPlayers {
+		t.Errorf("expected %q, got %q", resource.TextHotSeatAddPlayers, text)
+	}
+
+	bs.addPlayerName("Grandma")
+	for bs.state.curr() != stateKindScoring {
+		if text := bs.nextStage(); text != "" {
+			t.Fatalf("unexpected validation on stage %d: %q", bs.state.curr(), text)
+		}
+
+		if bs.state.curr() == stateKindVote {
+			t.Fatalf("expected vote stage to be skipped in hot seat mode")
+		}
+	}
+
+	bs.nextStage()
+	if bs.state.curr() != stateKindSummary {
+		t.Fatalf("expected summary stage, got %d", bs.state.curr())
+	}
+
+	// the letters are edited from the summary and the author returns to it
+	bs.reviewing = true
+	bs.state.seek(stateKindLetters)
+	for i := range bs.Letters {
+		bs.Letters[i].Status = false
+	}
+
+	if text := bs.nextStage(); text != resource.TextAddLeastOneLetterToComplete {
+		t.Errorf("expected %q, got %q", resource.TextAddLeastOneLetterToComplete, text)
+	}
+
+	bs.Letters[0].Status = true
+	if bs.nextStage(); bs.state.curr() != stateKindSummary {
+		t.Errorf("expected summary stage, got %d", bs.state.curr())
+	}
+}
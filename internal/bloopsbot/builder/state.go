@@ -0,0 +1,53 @@
+package builder
+
+import (
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/builderstate/model"
+)
+
+// Serialize the snapshot of the unfinished settings to continue after the restart of the bot
+func (bs *Session) Serialize() model.State {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+
+	s := model.State{
+		AuthorID:    bs.AuthorID,
+		AuthorName:  bs.AuthorName,
+		ChatID:      bs.ChatID,
+		Stage:       uint8(bs.state.curr()),
+		Categories:  make([]resource.Category, len(bs.Categories)),
+		Letters:     make([]resource.Letter, len(bs.Letters)),
+		RoundsNum:   bs.RoundsNum,
+		RoundTime:   bs.RoundTime,
+		Vote:        bs.Vote,
+		VoteMode:    uint8(bs.VoteMode),
+		VoteTime:    bs.VoteTime,
+		Bloops:      bs.Bloops,
+		Scoring:     uint8(bs.Scoring),
+		HotSeat:     bs.HotSeat,
+		LiveBoard:   bs.LiveBoard,
+		TieBreakers: match.SerializeTieBreakers(bs.TieBreakers),
+		SuddenDeath: bs.SuddenDeath,
+		PlayerNames: make([]string, len(bs.PlayerNames)),
+		CreatedAt:   bs.CreatedAt,
+	}
+
+	copy(s.Categories, bs.Categories)
+	copy(s.Letters, bs.Letters)
+	copy(s.PlayerNames, bs.PlayerNames)
+
+	return s
+}
+
+// SeekStage continues the restored session from the stage it was interrupted on
+func (bs *Session) SeekStage(stage uint8) {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.state.seek(stateKind(stage))
+}
+
+// Done is closed when the session has stopped and its result or snapshot is handed over
+func (bs *Session) Done() <-chan struct{} {
+	return bs.done
+}
@@ -48,7 +48,6 @@ func (s *stateMachine) isMin() bool {
 	return s.state == s.min
 }
 
-// nolint
 func (s *stateMachine) seek(kind stateKind) {
 	for e := s.transitions.Front(); e != nil; e = e.Next() {
 		if e.Value == kind {
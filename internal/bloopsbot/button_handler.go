@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/builder"
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
@@ -13,6 +14,12 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
+const (
+	hardLettersNum = 3
+	// the global stat of the letter is not reliable until it is played enough
+	hardLetterMinRounds = 20
+)
+
 func (m *manager) handleRulesButton(_ userModel.User, chatID int64) error {
 	msgText := resource.TextRulesMsg
 	msg := tgbotapi.NewMessage(chatID, msgText)
@@ -44,6 +51,13 @@ func (m *manager) handleCreateButton(u userModel.User, chatID int64) error {
 		return fmt.Errorf("new builder session: %w", err)
 	}
 
+	difficulty, err := m.statDB.FetchDifficulty()
+	if err != nil && !errors.Is(err, statDb.ErrNotFound) {
+		return fmt.Errorf("fetch difficulty: %w", err)
+	}
+
+	session.HardLetters = difficulty.Letters.Hardest(hardLettersNum, hardLetterMinRounds)
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 	delete(m.commandCbHandlers, u.ID)
@@ -75,12 +89,21 @@ func (m *manager) handleProfileButton(u userModel.User, chatID int64) error {
 		return fmt.Errorf("fetch profile stat: %w", err)
 	}
 
-	msg := tgbotapi.NewMessage(chatID, renderProfile(u, stat))
+	achievements, err := m.fetchAchievements(u.ID)
+	if err != nil {
+		return fmt.Errorf("fetch achievements: %w", err)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, renderProfile(u, stat, achievements))
 	msg.ParseMode = tgbotapi.ModeMarkdown
 	if _, err := m.tg.Send(msg); err != nil {
 		return fmt.Errorf("send msg: %w", err)
 	}
 
+	if err := m.sendProfileChart(u, chatID); err != nil {
+		return fmt.Errorf("send profile chart: %w", err)
+	}
+
 	return nil
 }
 
@@ -91,50 +114,54 @@ func (m *manager) handleJoinButton(u userModel.User, chatID int64) error {
 		return fmt.Errorf("send msg: %w", err)
 	}
 
-	m.registerCommandCbHandler(u.ID, func(msg string) error {
-		n, err := strconv.Atoi(msg)
-		if err != nil {
-			return fmt.Errorf("strconv: %w", err)
-		}
+	m.registerCommandCbHandler(u.ID, func(code string) error {
+		return m.joinMatch(u, chatID, code)
+	})
 
-		if session, ok := m.matchSession(int64(n)); ok {
-			if err := session.AddPlayer(matchstateModel.NewPlayer(chatID, u, false)); err != nil {
-				return fmt.Errorf("add player: %w", err)
-			}
-
-			greetingText := resource.TextJoinedGameMsg
-
-			row := tgbotapi.NewKeyboardButtonRow()
-			if session.Config.AuthorID == u.ID {
-				greetingText += resource.TextAuthorGreetingMsg
-				row = append(row, resource.StartButton)
-			}
-
-			row = append(row, resource.LeaveButton, resource.GameSettingButton)
-			msg := tgbotapi.NewMessage(chatID, greetingText)
-			msg.ParseMode = tgbotapi.ModeMarkdown
-			msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
-				row,
-				tgbotapi.NewKeyboardButtonRow(resource.RatingButton, resource.RulesButton),
-			)
-
-			if _, err := m.tg.Send(msg); err != nil {
-				return fmt.Errorf("send msg: %w", err)
-			}
-
-			m.mtx.Lock()
-			m.userMatchSessions[u.ID] = session
-			delete(m.commandCbHandlers, u.ID)
-			m.mtx.Unlock()
-		} else {
-			msg := tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFoundMsg)
-			if _, err := m.tg.Send(msg); err != nil {
-				return fmt.Errorf("send msg: %w", err)
-			}
+	return nil
+}
+
+// joinMatch /join 1234, the prompt stays until the right code is sent
+func (m *manager) joinMatch(u userModel.User, chatID int64, code string) error {
+	n, err := strconv.ParseInt(strings.TrimSpace(code), 10, 64)
+	session, ok := m.matchSession(n)
+	if err != nil || !ok {
+		msg := tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFoundMsg)
+		if _, err := m.tg.Send(msg); err != nil {
+			return fmt.Errorf("send msg: %w", err)
 		}
 
 		return nil
-	})
+	}
+
+	if err := session.AddPlayer(matchstateModel.NewPlayer(chatID, u, false)); err != nil {
+		return fmt.Errorf("add player: %w", err)
+	}
+
+	greetingText := resource.TextJoinedGameMsg
+
+	row := tgbotapi.NewKeyboardButtonRow()
+	if session.Config.AuthorID == u.ID {
+		greetingText += resource.TextAuthorGreetingMsg
+		row = append(row, resource.StartButton)
+	}
+
+	row = append(row, resource.LeaveButton, resource.GameSettingButton)
+	msg := tgbotapi.NewMessage(chatID, greetingText)
+	msg.ParseMode = tgbotapi.ModeMarkdown
+	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
+		row,
+		tgbotapi.NewKeyboardButtonRow(resource.RatingButton, resource.RulesButton),
+	)
+
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.mtx.Lock()
+	m.userMatchSessions[u.ID] = session
+	delete(m.commandCbHandlers, u.ID)
+	m.mtx.Unlock()
 
 	return nil
 }
@@ -0,0 +1,94 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/card"
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/logging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// the chart of one game is a single dot
+const minChartGames = 2
+
+// matchFinishFn the result card is sent to everyone who played from its own phone
+func (m *manager) matchFinishFn(ctx context.Context, session *match.Session) error {
+	scores := session.Scores()
+	result := card.MatchResult{
+		Code:   session.Config.Code,
+		Rounds: session.CurrRoundIdx + 1,
+		Scores: make([]card.Score, len(scores)),
+	}
+
+	for i, score := range scores {
+		result.Scores[i] = card.Score{
+			Name:   score.Player.User.FirstName,
+			Points: score.Points,
+			Bloops: score.Bloops,
+			Place:  score.Place,
+		}
+	}
+
+	b, err := m.cards.RenderMatch(result)
+	if err != nil {
+		return fmt.Errorf("render match card: %w", err)
+	}
+
+	// the photo that has not been delivered does not stop the others
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.card")
+	sent := map[int64]struct{}{}
+	for _, player := range session.Players {
+		if _, ok := sent[player.ChatID]; ok || player.Offline || !player.IsPlaying() {
+			continue
+		}
+
+		sent[player.ChatID] = struct{}{}
+		if err := m.sendPhoto(player.ChatID, b); err != nil {
+			logger.Errorf("send match card to %d: %v", player.ChatID, err)
+		}
+	}
+
+	return nil
+}
+
+// sendProfileChart the points of the last games of the user, nothing is sent for the short history
+func (m *manager) sendProfileChart(u userModel.User, chatID int64) error {
+	stats, err := m.statDB.FetchByuserID(u.ID)
+	if err != nil {
+		if errors.Is(err, statDb.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("fetch stat by userID: %w", err)
+	}
+
+	if len(stats) < minChartGames {
+		return nil
+	}
+
+	points := make([]card.ChartPoint, len(stats))
+	for i, stat := range stats {
+		points[i] = card.ChartPoint{At: stat.CreatedAt, Points: stat.SumPoints}
+	}
+
+	b, err := m.cards.RenderProfileChart(u.FirstName, points)
+	if err != nil {
+		return fmt.Errorf("render profile chart: %w", err)
+	}
+
+	return m.sendPhoto(chatID, b)
+}
+
+func (m *manager) sendPhoto(chatID int64, b []byte) error {
+	msg := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "bloops.png", Bytes: b})
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send photo: %w", err)
+	}
+
+	return nil
+}
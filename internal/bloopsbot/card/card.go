@@ -0,0 +1,213 @@
+package card
+
+import (
+	"bytes"
+	_ "embed" // fonts and backgrounds of the cards
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	width  = 1200
+	height = 800
+	// the text is drawn in pixels, one point is one pixel
+	dpi = 72
+)
+
+var (
+	//go:embed static/fonts/Go-Regular.ttf
+	regularTTF []byte
+	//go:embed static/fonts/Go-Bold.ttf
+	boldTTF []byte
+	//go:embed static/background.png
+	backgroundPNG []byte
+)
+
+var (
+	colorText   = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorMuted  = color.RGBA{R: 0xb9, G: 0xaa, B: 0xe0, A: 0xff}
+	colorGrid   = color.RGBA{R: 0x4a, G: 0x3a, B: 0x78, A: 0xff}
+	colorAccent = color.RGBA{R: 0xff, G: 0xc8, B: 0x3d, A: 0xff}
+	colorGem    = color.RGBA{R: 0x4d, G: 0xd9, B: 0xff, A: 0xff}
+	colorPlaces = []color.RGBA{
+		{R: 0xf5, G: 0xc2, B: 0x2b, A: 0xff},
+		{R: 0xc7, G: 0xcd, B: 0xd6, A: 0xff},
+		{R: 0xd0, G: 0x8a, B: 0x4e, A: 0xff},
+	}
+)
+
+// New parses the embedded assets, the renderer is safe for concurrent use
+func New() (*Renderer, error) {
+	regular, err := opentype.Parse(regularTTF)
+	if err != nil {
+		return nil, fmt.Errorf("parse regular font: %w", err)
+	}
+
+	bold, err := opentype.Parse(boldTTF)
+	if err != nil {
+		return nil, fmt.Errorf("parse bold font: %w", err)
+	}
+
+	background, err := png.Decode(bytes.NewReader(backgroundPNG))
+	if err != nil {
+		return nil, fmt.Errorf("decode background: %w", err)
+	}
+
+	return &Renderer{regular: regular, bold: bold, background: background}, nil
+}
+
+// Renderer draws PNG images of the game results without network and cgo
+type Renderer struct {
+	regular    *opentype.Font
+	bold       *opentype.Font
+	background image.Image
+}
+
+// canvas one image being drawn, the faces are not safe for concurrent use and live with the canvas
+type canvas struct {
+	img   *image.RGBA
+	faces map[faceKey]font.Face
+	r     *Renderer
+}
+
+type faceKey struct {
+	bold bool
+	size float64
+}
+
+func (r *Renderer) newCanvas() *canvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.ApproxBiLinear.Scale(img, img.Bounds(), r.background, r.background.Bounds(), draw.Src, nil)
+
+	return &canvas{img: img, faces: map[faceKey]font.Face{}, r: r}
+}
+
+func (c *canvas) face(bold bool, size float64) (font.Face, error) {
+	key := faceKey{bold: bold, size: size}
+	if f, ok := c.faces[key]; ok {
+		return f, nil
+	}
+
+	f := c.r.regular
+	if bold {
+		f = c.r.bold
+	}
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: dpi, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("new face: %w", err)
+	}
+
+	c.faces[key] = face
+
+	return face, nil
+}
+
+func (c *canvas) close() {
+	for _, f := range c.faces {
+		_ = f.Close()
+	}
+}
+
+func (c *canvas) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.img); err != nil {
+		return nil, fmt.Errorf("png encode: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// text draws the text with the baseline at y, align is the share of the width left of x: 0, 0.5 or 1
+func (c *canvas) text(s string, bold bool, size float64, x, y int, align float64, col color.Color) error {
+	face, err := c.face(bold, size)
+	if err != nil {
+		return err
+	}
+
+	d := font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: face}
+	w := d.MeasureString(s).Round()
+	d.Dot = fixed.P(x-int(float64(w)*align), y)
+	d.DrawString(s)
+
+	return nil
+}
+
+// fit cuts the text with the ellipsis to fit the width
+func (c *canvas) fit(s string, bold bool, size float64, maxWidth int) (string, error) {
+	face, err := c.face(bold, size)
+	if err != nil {
+		return "", err
+	}
+
+	if font.MeasureString(face, s).Round() <= maxWidth {
+		return s, nil
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if font.MeasureString(face, string(runes)+"…").Round() <= maxWidth {
+			break
+		}
+	}
+
+	return string(runes) + "…", nil
+}
+
+func (c *canvas) rect(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+func (c *canvas) circle(cx, cy, radius int, col color.Color) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx*dx+dy*dy <= radius*radius {
+				c.img.Set(cx+dx, cy+dy, col)
+			}
+		}
+	}
+}
+
+// gem the bloops icon
+func (c *canvas) gem(cx, cy, radius int, col color.Color) {
+	for dy := -radius; dy <= radius; dy++ {
+		half := radius - abs(dy)
+		c.rect(image.Rect(cx-half, cy+dy, cx+half+1, cy+dy+1), col)
+	}
+}
+
+// line the thick line from dots, enough for the charts
+func (c *canvas) line(x0, y0, x1, y1, thickness int, col color.Color) {
+	steps := abs(x1 - x0)
+	if dy := abs(y1 - y0); dy > steps {
+		steps = dy
+	}
+
+	if steps == 0 {
+		c.circle(x0, y0, thickness/2, col)
+		return
+	}
+
+	for i := 0; i <= steps; i++ {
+		x := x0 + (x1-x0)*i/steps
+		y := y0 + (y1-y0)*i/steps
+		c.circle(x, y, thickness/2, col)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
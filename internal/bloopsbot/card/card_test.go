@@ -0,0 +1,84 @@
+package card
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("new renderer: %v", err)
+	}
+
+	scores := []Score{
+		{Name: "Маша", Points: 42, Bloops: 7},
+		{Name: "Петя", Points: 30, Bloops: 1},
+		{Name: "Очень длинное имя игрока, которое не помещается", Points: 30},
+		{Name: "Бабушка", Points: 12},
+		{Name: "Вася", Points: -3},
+	}
+
+	at := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name   string
+		render func() ([]byte, error)
+	}{
+		{name: "match", render: func() ([]byte, error) {
+			return r.RenderMatch(MatchResult{Code: 1234, Rounds: 3, Scores: scores})
+		}},
+		{name: "match_single_player", render: func() ([]byte, error) {
+			return r.RenderMatch(MatchResult{Code: 1234, Rounds: 1, Scores: scores[:1]})
+		}},
+		{name: "chart", render: func() ([]byte, error) {
+			return r.RenderProfileChart("Маша", []ChartPoint{
+				{At: at.Add(48 * time.Hour), Points: 12},
+				{At: at, Points: 30},
+				{At: at.Add(24 * time.Hour), Points: -5},
+			})
+		}},
+		{name: "chart_empty", render: func() ([]byte, error) {
+			return r.RenderProfileChart("Маша", nil)
+		}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			b, err := tc.render()
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+
+			img, err := png.Decode(bytes.NewReader(b))
+			if err != nil {
+				t.Fatalf("png decode: %v", err)
+			}
+
+			if size := img.Bounds().Size(); size.X != width || size.Y != height {
+				t.Errorf("unexpected size %v", size)
+			}
+		})
+	}
+}
+
+func TestScorePlaces(t *testing.T) {
+	t.Parallel()
+
+	places := scorePlaces([]Score{{Points: 10}, {Points: 10}, {Points: 7}, {Points: 3}, {Points: 3}})
+	if expected := []int{1, 1, 3, 4, 4}; !reflect.DeepEqual(places, expected) {
+		t.Errorf("expected %v, got %v", expected, places)
+	}
+
+	// the tie-breakers of the match have separated the players with the same points
+	places = scorePlaces([]Score{{Points: 10, Place: 1}, {Points: 10, Place: 2}, {Points: 7, Place: 3}})
+	if expected := []int{1, 2, 3}; !reflect.DeepEqual(places, expected) {
+		t.Errorf("expected %v, got %v", expected, places)
+	}
+}
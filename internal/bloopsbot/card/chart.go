@@ -0,0 +1,129 @@
+package card
+
+import (
+	"image"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// the plot area of the chart
+const (
+	plotLeft   = 120
+	plotRight  = width - 60
+	plotTop    = 180
+	plotBottom = height - 100
+	gridLines  = 5
+	// the chart of the long history is unreadable, only the last games are drawn
+	maxChartPoints = 30
+)
+
+// ChartPoint the points of one game
+type ChartPoint struct {
+	At     time.Time
+	Points int
+}
+
+// RenderProfileChart the chart of the points over the last games of the player
+func (r *Renderer) RenderProfileChart(name string, points []ChartPoint) ([]byte, error) {
+	points = lastPoints(points, maxChartPoints)
+
+	c := r.newCanvas()
+	defer c.close()
+
+	title, err := c.fit(name, true, 48, width-120)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.text(title, true, 48, 60, 90, 0, colorText); err != nil {
+		return nil, err
+	}
+
+	if err := c.text("Очки за последние игры", false, 28, 60, 135, 0, colorMuted); err != nil {
+		return nil, err
+	}
+
+	maxPoints := axisMax(points)
+	for i := 0; i <= gridLines; i++ {
+		y := plotBottom - (plotBottom-plotTop)*i/gridLines
+		c.rect(image.Rect(plotLeft, y, plotRight, y+2), colorGrid)
+		if err := c.text(strconv.Itoa(maxPoints*i/gridLines), false, 22, plotLeft-16, y+8, 1, colorMuted); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(points) == 0 {
+		return c.encode()
+	}
+
+	xs := make([]int, len(points))
+	ys := make([]int, len(points))
+	for i, p := range points {
+		xs[i] = plotLeft + 20
+		if len(points) > 1 {
+			xs[i] += (plotRight - plotLeft - 40) * i / (len(points) - 1)
+		}
+
+		// the penalties are drawn at the bottom of the axis
+		v := p.Points
+		if v < 0 {
+			v = 0
+		}
+
+		ys[i] = plotBottom - (plotBottom-plotTop)*v/maxPoints
+	}
+
+	for i := 1; i < len(points); i++ {
+		c.line(xs[i-1], ys[i-1], xs[i], ys[i], 6, colorAccent)
+	}
+
+	for i := range points {
+		c.circle(xs[i], ys[i], 9, colorText)
+		c.circle(xs[i], ys[i], 5, colorAccent)
+	}
+
+	// the dates of the first and the last game are enough to read the period
+	if err := c.text(points[0].At.Format("02.01.06"), false, 22, xs[0], plotBottom+40, 0.5, colorMuted); err != nil {
+		return nil, err
+	}
+
+	if last := len(points) - 1; last > 0 {
+		if err := c.text(points[last].At.Format("02.01.06"), false, 22, xs[last], plotBottom+40, 0.5, colorMuted); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.encode()
+}
+
+// lastPoints the last n points in the time order
+func lastPoints(points []ChartPoint, n int) []ChartPoint {
+	sorted := make([]ChartPoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].At.Before(sorted[j].At)
+	})
+
+	if len(sorted) > n {
+		sorted = sorted[len(sorted)-n:]
+	}
+
+	return sorted
+}
+
+// axisMax the top of the axis rounded up to a multiple of the grid lines, never zero
+func axisMax(points []ChartPoint) int {
+	max := 0
+	for _, p := range points {
+		if p.Points > max {
+			max = p.Points
+		}
+	}
+
+	if max <= 0 {
+		return gridLines
+	}
+
+	return (max + gridLines - 1) / gridLines * gridLines
+}
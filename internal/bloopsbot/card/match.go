@@ -0,0 +1,171 @@
+package card
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+)
+
+const (
+	podiumBottom = 560
+	podiumWidth  = 240
+	// the gems above the points, the rest is shown as a number
+	maxGems = 5
+	// the players below the podium
+	maxListed = 6
+)
+
+// podium the centers and the heights of the blocks for the 1st, 2nd and 3rd places
+var podium = []struct{ x, height int }{
+	{x: 600, height: 300},
+	{x: 330, height: 220},
+	{x: 870, height: 170},
+}
+
+// Score the result of the player on the card
+type Score struct {
+	Name   string
+	Points int
+	// the bloopses completed by the player
+	Bloops int
+	// the place of the ranking, the places are counted by the points if zero
+	Place int
+}
+
+// MatchResult the scores are sorted by points, the best first
+type MatchResult struct {
+	Code   int64
+	Rounds int
+	Scores []Score
+}
+
+// RenderMatch the shareable end of match card with the podium
+func (r *Renderer) RenderMatch(result MatchResult) ([]byte, error) {
+	c := r.newCanvas()
+	defer c.close()
+
+	if err := c.text("BLOOPS", true, 56, 60, 100, 0, colorAccent); err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("Игра %d · раундов: %d", result.Code, result.Rounds)
+	if err := c.text(header, false, 28, width-60, 96, 1, colorMuted); err != nil {
+		return nil, err
+	}
+
+	places := scorePlaces(result.Scores)
+	for i, score := range result.Scores {
+		if i >= len(podium) {
+			break
+		}
+
+		if err := c.drawPodium(i, places[i], score); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.drawList(result.Scores, places); err != nil {
+		return nil, err
+	}
+
+	return c.encode()
+}
+
+func (c *canvas) drawPodium(idx, place int, score Score) error {
+	p := podium[idx]
+	top := podiumBottom - p.height
+	c.rect(image.Rect(p.x-podiumWidth/2, top, p.x+podiumWidth/2, podiumBottom), colorPlaces[place-1])
+	if err := c.text(strconv.Itoa(place), true, 96, p.x, top+110, 0.5, colorText); err != nil {
+		return err
+	}
+
+	name, err := c.fit(score.Name, true, 32, podiumWidth+20)
+	if err != nil {
+		return err
+	}
+
+	if err := c.text(name, true, 32, p.x, top-60, 0.5, colorText); err != nil {
+		return err
+	}
+
+	if err := c.text(fmt.Sprintf("%d очков", score.Points), false, 26, p.x, top-22, 0.5, colorMuted); err != nil {
+		return err
+	}
+
+	return c.drawGems(p.x, top-120, score.Bloops)
+}
+
+// drawGems the row of the bloops icons centered at x
+func (c *canvas) drawGems(x, y, n int) error {
+	if n == 0 {
+		return nil
+	}
+
+	shown := n
+	if shown > maxGems {
+		shown = maxGems
+	}
+
+	const radius, step = 12, 30
+	left := x - (shown-1)*step/2
+	for i := 0; i < shown; i++ {
+		c.gem(left+i*step, y, radius, colorGem)
+	}
+
+	if n > shown {
+		return c.text("+"+strconv.Itoa(n-shown), true, 24, left+shown*step, y+9, 0, colorGem)
+	}
+
+	return nil
+}
+
+// drawList the players below the podium in two columns
+func (c *canvas) drawList(scores []Score, places []int) error {
+	if len(scores) <= len(podium) {
+		return nil
+	}
+
+	rest := scores[len(podium):]
+	restPlaces := places[len(podium):]
+	for i, score := range rest {
+		if i == maxListed {
+			more := fmt.Sprintf("и еще игроков: %d", len(rest)-maxListed)
+			return c.text(more, false, 24, width/2, height-30, 0.5, colorMuted)
+		}
+
+		x, y := 120+(i%2)*500, 620+(i/2)*44
+		name, err := c.fit(score.Name, false, 28, 320)
+		if err != nil {
+			return err
+		}
+
+		line := fmt.Sprintf("%d. %s", restPlaces[i], name)
+		if err := c.text(line, false, 28, x, y, 0, colorText); err != nil {
+			return err
+		}
+
+		if err := c.text(strconv.Itoa(score.Points), true, 28, x+440, y, 1, colorAccent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scorePlaces the places of the sorted scores, the players with the same points share the place
+func scorePlaces(scores []Score) []int {
+	places := make([]int, len(scores))
+	for i := range scores {
+		if scores[i].Place > 0 {
+			places[i] = scores[i].Place
+			continue
+		}
+
+		places[i] = i + 1
+		if i > 0 && scores[i].Points == scores[i-1].Points {
+			places[i] = places[i-1]
+		}
+	}
+
+	return places
+}
@@ -6,7 +6,7 @@ import (
 	"strings"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
-	matchstateModel "github.com/bloops-games/bloops/internal/database/matchstate/model"
+	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
 	userDb "github.com/bloops-games/bloops/internal/database/user/database"
 	userModel "github.com/bloops-games/bloops/internal/database/user/model"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
@@ -24,159 +24,110 @@ func (m *manager) handleStartCommand(u userModel.User, chatID int64) error {
 	return nil
 }
 
-func (m *manager) handleBanCommand(u userModel.User, chatID int64) error {
-	msg := tgbotapi.NewMessage(chatID, resource.TextBanMsg)
-	msg.ReplyMarkup = resource.CommonButtons
+func (m *manager) handleProfileCmd(u userModel.User, chatID int64) error {
+	msg := tgbotapi.NewMessage(chatID, resource.TextSendProfileMsg)
 	if _, err := m.tg.Send(msg); err != nil {
 		return fmt.Errorf("send msg: %w", err)
 	}
 
-	m.registerCommandCbHandler(u.ID, func(msg string) error {
-		msg = strings.TrimPrefix(msg, "@")
-		banned, err := m.userDB.FetchByUsername(msg)
-		if err != nil {
-			if errors.Is(err, userDb.ErrNotFound) {
-				if _, err := m.tg.Send(tgbotapi.NewMessage(u.ID, fmt.Sprintf("Пользователь не найден: %s", msg))); err != nil {
-					return fmt.Errorf("send msg: %w", err)
-				}
-			}
+	m.registerCommandCbHandler(u.ID, func(username string) error {
+		return m.sendUserProfile(u, chatID, username)
+	})
 
-			return fmt.Errorf("fetch by username: %w", err)
-		}
+	return nil
+}
 
-		if banned.Admin {
-			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, "Нельзя забанить администратора")); err != nil {
+// sendUserProfile /profile @username
+func (m *manager) sendUserProfile(u userModel.User, chatID int64, username string) error {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+
+	profile, err := m.userDB.FetchByUsername(username)
+	if err != nil {
+		if errors.Is(err, userDb.ErrNotFound) {
+			msg := tgbotapi.NewMessage(chatID, resource.TextProfileCmdUserNotFound)
+			msg.ParseMode = tgbotapi.ModeMarkdown
+			if _, err := m.tg.Send(msg); err != nil {
 				return fmt.Errorf("send msg: %w", err)
 			}
-
 			return nil
 		}
 
-		banned.Status = userModel.StatusBanned
-		if err := m.userDB.Store(banned); err != nil {
-			return fmt.Errorf("user db store: %w", err)
-		}
-
-		if _, err := m.tg.Send(tgbotapi.NewMessage(u.ID, fmt.Sprintf("Пользователь забанен: %s", msg))); err != nil {
-			return fmt.Errorf("send msg: %w", err)
-		}
+		return fmt.Errorf("fetch by username: %w", err)
+	}
 
-		m.mtx.Lock()
-		defer m.mtx.Unlock()
-		delete(m.commandCbHandlers, u.ID)
+	m.deleteCommandCbHandler(u.ID)
 
-		return nil
-	})
+	stat, err := m.statDB.FetchProfileStat(profile.ID)
+	if err != nil && !errors.Is(err, statDb.ErrNotFound) {
+		return fmt.Errorf("fetch profile stat: %w", err)
+	}
 
-	return nil
-}
+	achievements, err := m.fetchAchievements(profile.ID)
+	if err != nil {
+		return fmt.Errorf("fetch achievements: %w", err)
+	}
 
-func (m *manager) handleProfileCmd(u userModel.User, chatID int64) error {
-	msg := tgbotapi.NewMessage(chatID, resource.TextSendProfileMsg)
+	msg := tgbotapi.NewMessage(chatID, renderProfile(profile, stat, achievements))
+	msg.ParseMode = tgbotapi.ModeMarkdown
 	if _, err := m.tg.Send(msg); err != nil {
 		return fmt.Errorf("send msg: %w", err)
 	}
 
-	m.registerCommandCbHandler(u.ID, func(username string) error {
-		username = strings.TrimPrefix(username, "@")
-
-		u, err := m.userDB.FetchByUsername(username)
-		if err != nil {
-			if errors.Is(err, userDb.ErrNotFound) {
-				msg := tgbotapi.NewMessage(chatID, resource.TextProfileCmdUserNotFound)
-				msg.ParseMode = tgbotapi.ModeMarkdown
-				if _, err := m.tg.Send(msg); err != nil {
-					return fmt.Errorf("send msg: %w", err)
-				}
-				return nil
-			}
-
-			return fmt.Errorf("fetch by username: %w", err)
-		}
+	if err := m.sendProfileChart(profile, chatID); err != nil {
+		return fmt.Errorf("send profile chart: %w", err)
+	}
 
-		stat, err := m.statDB.FetchProfileStat(u.ID)
-		if err != nil {
-			return fmt.Errorf("fetch profile stat: %w", err)
-		}
+	return nil
+}
 
-		msg := tgbotapi.NewMessage(chatID, renderProfile(u, stat))
-		msg.ParseMode = tgbotapi.ModeMarkdown
+func (m *manager) handleRegisterOfflinePlayerCmd(u userModel.User, chatID int64) error {
+	if _, ok := m.userMatchSession(u.ID); !ok {
+		msg := tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFound)
 		if _, err := m.tg.Send(msg); err != nil {
 			return fmt.Errorf("send msg: %w", err)
 		}
 
-		m.mtx.Lock()
-		defer m.mtx.Unlock()
-		delete(m.commandCbHandlers, u.ID)
-
 		return nil
-	})
-
-	return nil
-}
+	}
 
-func (m *manager) handleFeedbackCommand(u userModel.User, chatID int64) error {
-	msg := tgbotapi.NewMessage(chatID, resource.TextFeedbackMsg)
-	msg.ReplyMarkup = resource.CommonButtons
+	msg := tgbotapi.NewMessage(chatID, resource.TextSendOfflinePlayerUsernameMsg)
 	if _, err := m.tg.Send(msg); err != nil {
 		return fmt.Errorf("send msg: %w", err)
 	}
 
-	m.registerCommandCbHandler(u.ID, func(msg string) error {
-		if m.config.Admin != "" {
-			admin, err := m.userDB.FetchByUsername(m.config.Admin)
-			if err != nil {
-				return fmt.Errorf("fetch by username: %w", err)
-			}
-
-			if _, err := m.tg.Send(tgbotapi.NewMessage(
-				admin.ID,
-				fmt.Sprintf("Прилетел фидбек от пользователя: %s", msg),
-			)); err != nil {
-				return fmt.Errorf("send msg: %w", err)
-			}
-		}
-
-		m.mtx.Lock()
-		defer m.mtx.Unlock()
-		delete(m.commandCbHandlers, u.ID)
-
-		return nil
+	m.registerCommandCbHandler(u.ID, func(name string) error {
+		return m.addOfflinePlayer(u, chatID, name)
 	})
 
+	if err := m.sendSavedGuests(u, chatID); err != nil {
+		return fmt.Errorf("send saved guests: %w", err)
+	}
+
 	return nil
 }
 
-func (m *manager) handleRegisterOfflinePlayerCmd(u userModel.User, chatID int64) error {
-	if session, ok := m.userMatchSession(u.ID); ok {
-		msg := tgbotapi.NewMessage(chatID, resource.TextSendOfflinePlayerUsernameMsg)
+// addOfflinePlayer /add Grandma
+func (m *manager) addOfflinePlayer(u userModel.User, chatID int64, name string) error {
+	session, ok := m.userMatchSession(u.ID)
+	if !ok {
+		m.deleteCommandCbHandler(u.ID)
+		msg := tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFound)
 		if _, err := m.tg.Send(msg); err != nil {
 			return fmt.Errorf("send msg: %w", err)
 		}
 
-		m.registerCommandCbHandler(u.ID, func(username string) error {
-			u.FirstName = username
-
-			if err := session.AddPlayer(matchstateModel.NewPlayer(chatID, u, true)); err != nil {
-				return err
-			}
+		return nil
+	}
 
-			msg := tgbotapi.NewMessage(chatID, resource.TextOfflinePlayerAdded)
-			if _, err := m.tg.Send(msg); err != nil {
-				return fmt.Errorf("send msg: %w", err)
-			}
+	if err := m.addGuest(session, chatID, u, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("add guest: %w", err)
+	}
 
-			m.mtx.Lock()
-			defer m.mtx.Unlock()
-			delete(m.commandCbHandlers, u.ID)
+	m.deleteCommandCbHandler(u.ID)
 
-			return nil
-		})
-	} else {
-		msg := tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFound)
-		if _, err := m.tg.Send(msg); err != nil {
-			return fmt.Errorf("send msg: %w", err)
-		}
+	msg := tgbotapi.NewMessage(chatID, resource.TextOfflinePlayerAdded)
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,34 @@
+package bloopsbot
+
+import (
+	"strings"
+	"unicode"
+)
+
+// parseCommand splits the text command into the command and its inline arguments,
+// the bot mention of the command is dropped: /join@bloops_bot 1234
+// button texts are returned as is
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return text, ""
+	}
+
+	cmd, args := splitArg(text)
+	if idx := strings.IndexByte(cmd, '@'); idx != -1 {
+		cmd = cmd[:idx]
+	}
+
+	return cmd, args
+}
+
+// splitArg returns the first argument and the rest of the arguments as is
+func splitArg(args string) (string, string) {
+	args = strings.TrimSpace(args)
+	idx := strings.IndexFunc(args, unicode.IsSpace)
+	if idx == -1 {
+		return args, ""
+	}
+
+	return args[:idx], strings.TrimSpace(args[idx:])
+}
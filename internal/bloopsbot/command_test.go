@@ -0,0 +1,44 @@
+package bloopsbot
+
+import "testing"
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		text string
+		cmd  string
+		args string
+	}{
+		{name: "no_args", text: "/profile", cmd: "/profile"},
+		{name: "args", text: "/join 1234", cmd: "/join", args: "1234"},
+		{name: "bot_mention", text: "/join@bloops_bot  1234 ", cmd: "/join", args: "1234"},
+		{name: "rest_kept", text: "/ban @user 7d  spam in chat", cmd: "/ban", args: "@user 7d  spam in chat"},
+		{name: "button", text: "Создать игру", cmd: "Создать игру"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cmd, args := parseCommand(tc.text)
+			if cmd != tc.cmd || args != tc.args {
+				t.Errorf("expected %q %q, got %q %q", tc.cmd, tc.args, cmd, args)
+			}
+		})
+	}
+}
+
+func TestSplitArg(t *testing.T) {
+	t.Parallel()
+
+	head, tail := splitArg(" @user\t7d reason ")
+	if head != "@user" || tail != "7d reason" {
+		t.Errorf("expected %q %q, got %q %q", "@user", "7d reason", head, tail)
+	}
+
+	if head, tail := splitArg(""); head != "" || tail != "" {
+		t.Errorf("expected empty args, got %q %q", head, tail)
+	}
+}
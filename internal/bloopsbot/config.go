@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/webhook"
 )
 
 type Config struct {
@@ -26,10 +27,31 @@ type Config struct {
 	BotWebhookHookURL string `envconfig:"BLOOP_BOT_WEBHOOK_URL"`
 	// Telegram bot token
 	BotToken string `envconfig:"BLOOP_BOT_TOKEN"`
+	// Address of the Bot API, for example http://localhost:8081 for the offline stand-in from tools/tgstub-cli
+	// By default the requests go to api.telegram.org
+	BotAPIEndpoint string `envconfig:"BLOOP_BOT_API_ENDPOINT"`
 	// Waiting time to complete the game creation session
 	BuildingTimeout time.Duration `envconfig:"BLOOP_BUILDING_TIMEOUT" default:"60m"`
 	// Waiting time for the game session to end
 	PlayingTimeout   time.Duration `envconfig:"BLOOP_PLAYING_TIMEOUT" default:"24h"`
 	TgBotPollTimeout time.Duration `envconfig:"BLOOP_TG_BOT_POLL_TIMEOUT" default:"60s"`
-	DB               database.Config
+	// Number of the update queues, the updates of one user always go to the same queue and are handled in order
+	// By default 4 queues per CPU
+	DispatcherShards int `envconfig:"BLOOP_DISPATCHER_SHARDS"`
+	// Pending updates of one user, the extra ones are dropped, the queues are bounded by it
+	DispatcherUserQueueSize int `envconfig:"BLOOP_DISPATCHER_USER_QUEUE_SIZE" default:"16"`
+	// Updates per second allowed from one user, bursts up to FloodBurst updates, zero disables the limit
+	FloodRate  float64 `envconfig:"BLOOP_FLOOD_RATE" default:"3"`
+	FloodBurst int     `envconfig:"BLOOP_FLOOD_BURST" default:"10"`
+	// Waiting time for the answer to the command prompt, zero disables the expiration
+	PromptTimeout time.Duration `envconfig:"BLOOP_PROMPT_TIMEOUT" default:"5m"`
+	// Time given to the sessions to save their state on shutdown
+	ShutdownTimeout time.Duration `envconfig:"BLOOP_SHUTDOWN_TIMEOUT" default:"20s"`
+	// Number of broadcast messages sent per second, telegram allows about 30
+	BroadcastRate int `envconfig:"BLOOP_BROADCAST_RATE" default:"20"`
+	// Address of the presenter screen in the local network, for example http://192.168.1.10:1234
+	// By default the page is served on localhost and BLOOP_PORT
+	PresenterURL string `envconfig:"BLOOP_PRESENTER_URL"`
+	DB           database.Config
+	Webhook      webhook.Config
 }
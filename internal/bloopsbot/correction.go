@@ -0,0 +1,267 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	correctPlayerDataPrefix = "fix:p:"
+	correctRoundDataPrefix  = "fix:r:"
+	correctEditDataPrefix   = "fix:e:"
+	correctRevoteDataPrefix = "fix:v:"
+)
+
+// handleCorrectCmd the host chooses the player, the round and the action with the buttons of one message
+func (m *manager) handleCorrectCmd(u userModel.User, chatID int64) error {
+	session, ok := m.userMatchSession(u.ID)
+	if !ok {
+		return m.sendText(chatID, resource.TextGameRoomNotFound)
+	}
+
+	if session.Config.AuthorID != u.ID {
+		return m.sendText(chatID, resource.TextCorrectNotHostMsg)
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	for _, player := range session.CorrectionPlayers() {
+		if len(session.CorrectionRounds(player.UserID)) == 0 {
+			continue
+		}
+
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				player.User.FirstName,
+				correctPlayerDataPrefix+strconv.FormatInt(player.UserID, 10),
+			),
+		))
+	}
+
+	if len(markup.InlineKeyboard) == 0 {
+		return m.sendText(chatID, resource.TextCorrectNoRoundsMsg)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, resource.TextCorrectChoosePlayer)
+	msg.ReplyMarkup = markup
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		return m.handleCorrectQuery(u, chatID, output.MessageID, session, query)
+	})
+
+	return nil
+}
+
+func (m *manager) handleCorrectQuery(
+	u userModel.User,
+	chatID int64,
+	messageID int,
+	session *match.Session,
+	query *tgbotapi.CallbackQuery,
+) error {
+	if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(query.Data, correctPlayerDataPrefix):
+		userID, err := strconv.ParseInt(strings.TrimPrefix(query.Data, correctPlayerDataPrefix), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse user id: %w", err)
+		}
+
+		markup := tgbotapi.NewInlineKeyboardMarkup()
+		for _, round := range session.CorrectionRounds(userID) {
+			markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					fmt.Sprintf("%d. %s - %d", round.Idx+1, round.Letter, round.Points),
+					fmt.Sprintf("%s%d:%d", correctRoundDataPrefix, userID, round.Idx),
+				),
+			))
+		}
+
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, fmt.Sprintf(resource.TextCorrectChooseRound, m.correctPlayerName(session, userID)))
+		edit.ReplyMarkup = &markup
+		if _, err := m.tg.Send(edit); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+	case strings.HasPrefix(query.Data, correctRoundDataPrefix):
+		userID, roundIdx, err := parseCorrectData(strings.TrimPrefix(query.Data, correctRoundDataPrefix))
+		if err != nil {
+			return fmt.Errorf("parse correct data: %w", err)
+		}
+
+		var round match.CorrectionRound
+		for _, r := range session.CorrectionRounds(userID) {
+			if r.Idx == roundIdx {
+				round = r
+			}
+		}
+
+		data := fmt.Sprintf("%d:%d", userID, roundIdx)
+		row := tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(resource.TextCorrectEditButton, correctEditDataPrefix+data))
+		if session.Config.Vote {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(resource.TextCorrectRevoteButton, correctRevoteDataPrefix+data))
+		}
+
+		markup := tgbotapi.NewInlineKeyboardMarkup(row)
+		text := fmt.Sprintf(resource.TextCorrectChooseAction, roundIdx+1, m.correctPlayerName(session, userID), round.Letter, round.Points)
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		edit.ReplyMarkup = &markup
+		if _, err := m.tg.Send(edit); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+	case strings.HasPrefix(query.Data, correctEditDataPrefix):
+		userID, roundIdx, err := parseCorrectData(strings.TrimPrefix(query.Data, correctEditDataPrefix))
+		if err != nil {
+			return fmt.Errorf("parse correct data: %w", err)
+		}
+
+		m.deleteQueryCbHandler(chatID, messageID)
+		if _, err := m.tg.Send(tgbotapi.NewEditMessageText(chatID, messageID, resource.TextCorrectPointsMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		m.registerCommandCbHandler(u.ID, func(args string) error {
+			return m.correctPoints(u, chatID, session, userID, roundIdx, args)
+		})
+	case strings.HasPrefix(query.Data, correctRevoteDataPrefix):
+		userID, roundIdx, err := parseCorrectData(strings.TrimPrefix(query.Data, correctRevoteDataPrefix))
+		if err != nil {
+			return fmt.Errorf("parse correct data: %w", err)
+		}
+
+		m.deleteQueryCbHandler(chatID, messageID)
+		text := resource.TextCorrectRevoteMsg
+		if err := session.Revote(u.ID, userID, roundIdx); err != nil {
+			if text, err = correctionErrText(err, roundIdx, m.correctPlayerName(session, userID)); err != nil {
+				return fmt.Errorf("revote: %w", err)
+			}
+		}
+
+		if _, err := m.tg.Send(tgbotapi.NewEditMessageText(chatID, messageID, text)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// correctByArgs /fix Petya 2 5 the river does not exist
+func (m *manager) correctByArgs(u userModel.User, chatID int64, args string) error {
+	session, ok := m.userMatchSession(u.ID)
+	if !ok {
+		return m.sendText(chatID, resource.TextGameRoomNotFound)
+	}
+
+	name, args := splitArg(args)
+	roundArg, args := splitArg(args)
+	round, err := strconv.Atoi(roundArg)
+	if err != nil {
+		return m.sendText(chatID, resource.TextCorrectFormatMsg)
+	}
+
+	for _, player := range session.CorrectionPlayers() {
+		if strings.EqualFold(player.User.FirstName, name) {
+			return m.correctPoints(u, chatID, session, player.UserID, round-1, args)
+		}
+	}
+
+	return m.sendText(chatID, fmt.Sprintf(resource.TextCorrectRoundMsg, round, name))
+}
+
+// correctPoints the prompt stays until the host sends the points and the reason
+func (m *manager) correctPoints(u userModel.User, chatID int64, session *match.Session, userID int64, roundIdx int, args string) error {
+	pointsArg, reason := splitArg(args)
+	points, err := strconv.Atoi(pointsArg)
+	if err != nil || reason == "" {
+		return m.sendText(chatID, resource.TextCorrectFormatMsg)
+	}
+
+	m.deleteCommandCbHandler(u.ID)
+	if err := session.Correct(u.ID, userID, roundIdx, points, reason); err != nil {
+		text, err := correctionErrText(err, roundIdx, m.correctPlayerName(session, userID))
+		if err != nil {
+			return fmt.Errorf("correct: %w", err)
+		}
+
+		return m.sendText(chatID, text)
+	}
+
+	return nil
+}
+
+func (m *manager) correctPlayerName(session *match.Session, userID int64) string {
+	for _, player := range session.CorrectionPlayers() {
+		if player.UserID == userID {
+			return player.User.FirstName
+		}
+	}
+
+	return ""
+}
+
+func (m *manager) sendText(chatID int64, text string) error {
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+// correctionErrText the text for the host, the unexpected errors are returned
+func correctionErrText(err error, roundIdx int, name string) (string, error) {
+	switch {
+	case errors.Is(err, match.ErrNotHost):
+		return resource.TextCorrectNotHostMsg, nil
+	case errors.Is(err, match.ErrMatchNotPlaying):
+		return resource.TextCorrectNoGameMsg, nil
+	case errors.Is(err, match.ErrRateNotFound), errors.Is(err, match.ErrPlayerNotFound):
+		return fmt.Sprintf(resource.TextCorrectRoundMsg, roundIdx+1, name), nil
+	case errors.Is(err, match.ErrReasonRequired):
+		return resource.TextCorrectFormatMsg, nil
+	case errors.Is(err, match.ErrInvalidPoints):
+		return fmt.Sprintf(resource.TextCorrectPointsRangeMsg, match.MaxCorrectionPoints, match.MaxCorrectionPoints), nil
+	case errors.Is(err, match.ErrVoteDisabled):
+		return resource.TextCorrectVoteOffMsg, nil
+	case errors.Is(err, match.ErrVoteInProgress):
+		return resource.TextCorrectVoteBusyMsg, nil
+	case errors.Is(err, match.ErrNoVoters):
+		return resource.TextCorrectNoVotersMsg, nil
+	case errors.Is(err, match.ErrNothingToRevote):
+		return resource.TextCorrectNoPointsMsg, nil
+	default:
+		return "", err
+	}
+}
+
+// parseCorrectData user id and round index, "42:1"
+func parseCorrectData(data string) (int64, int, error) {
+	parts := strings.SplitN(data, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid data %q", data)
+	}
+
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse user id: %w", err)
+	}
+
+	roundIdx, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse round: %w", err)
+	}
+
+	return userID, roundIdx, nil
+}
@@ -0,0 +1,209 @@
+package bloopsbot
+
+import (
+	"context"
+	"expvar"
+	"strconv"
+	"sync"
+
+	"github.com/bloops-games/bloops/internal/logging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// served by the pprof server on /debug/vars
+var dispatcherMetrics = expvar.NewMap("bloopsbot_dispatcher")
+
+const (
+	metricQueued    = "queued"
+	metricProcessed = "processed"
+	metricDropped   = "dropped"
+)
+
+type updateHandlerFunc = func(ctx context.Context, upd tgbotapi.Update)
+
+// dispatcher processes the updates of the same user one by one in order, the updates of different users in parallel.
+// A user is always served by the same shard. The reading never waits for a shard: the pending updates
+// of one user are bounded by userQueueSize, and only the user over the limit loses the extra updates,
+// the other users of the same shard are not affected. The dropped updates are passed to drop
+func newDispatcher(shardsNum, userQueueSize int, handle, drop updateHandlerFunc) *dispatcher {
+	if shardsNum <= 0 {
+		shardsNum = 1
+	}
+
+	d := &dispatcher{
+		handle:        handle,
+		drop:          drop,
+		shards:        make([]*shard, shardsNum),
+		userQueueSize: userQueueSize,
+		pending:       map[int64]int{},
+	}
+
+	for i := range d.shards {
+		d.shards[i] = &shard{notify: make(chan struct{}, 1)}
+	}
+
+	return d
+}
+
+type dispatcher struct {
+	handle        updateHandlerFunc
+	drop          updateHandlerFunc
+	shards        []*shard
+	userQueueSize int
+
+	mtx sync.Mutex
+	// key: user or chat id, value: updates queued or in progress
+	pending map[int64]int
+}
+
+// shard the queue is bounded by the pending updates of its users
+type shard struct {
+	mtx   sync.Mutex
+	queue []tgbotapi.Update
+	// signals the worker that the queue is not empty
+	notify chan struct{}
+}
+
+func (s *shard) push(upd tgbotapi.Update) {
+	s.mtx.Lock()
+	s.queue = append(s.queue, upd)
+	s.mtx.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *shard) pop() (tgbotapi.Update, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.queue) == 0 {
+		return tgbotapi.Update{}, false
+	}
+
+	upd := s.queue[0]
+	s.queue[0] = tgbotapi.Update{}
+	s.queue = s.queue[1:]
+
+	return upd, true
+}
+
+func (s *shard) len() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.queue)
+}
+
+// run blocks until the context is done and all the shards have stopped
+func (d *dispatcher) run(ctx context.Context, updates tgbotapi.UpdatesChannel) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(d.shards))
+	for i := range d.shards {
+		go d.worker(ctx, wg, i)
+	}
+
+	defer wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case upd, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			d.dispatch(ctx, upd)
+		}
+	}
+}
+
+func (d *dispatcher) dispatch(ctx context.Context, upd tgbotapi.Update) {
+	key := updateKey(upd)
+	if !d.acquire(key) {
+		dispatcherMetrics.Add(metricDropped, 1)
+		logging.FromContext(ctx).Named("bloopsbot.dispatcher").Warnf("Too many pending updates from %d, update dropped", key)
+		if d.drop != nil {
+			d.drop(ctx, upd)
+		}
+
+		return
+	}
+
+	idx := d.shardIdx(key)
+	d.shards[idx].push(upd)
+	dispatcherMetrics.Add(metricQueued, 1)
+	dispatcherMetrics.Add(shardMetric(idx), 1)
+}
+
+func (d *dispatcher) worker(ctx context.Context, wg *sync.WaitGroup, idx int) {
+	defer wg.Done()
+	s := d.shards[idx]
+	for {
+		upd, ok := s.pop()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.notify:
+				continue
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		dispatcherMetrics.Add(metricQueued, -1)
+		dispatcherMetrics.Add(shardMetric(idx), -1)
+		d.handle(ctx, upd)
+		d.release(updateKey(upd))
+		dispatcherMetrics.Add(metricProcessed, 1)
+	}
+}
+
+func (d *dispatcher) shardIdx(key int64) int {
+	return int(uint64(key) % uint64(len(d.shards)))
+}
+
+func (d *dispatcher) acquire(key int64) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.userQueueSize > 0 && d.pending[key] >= d.userQueueSize {
+		return false
+	}
+
+	d.pending[key]++
+
+	return true
+}
+
+func (d *dispatcher) release(key int64) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.pending[key]--
+	if d.pending[key] <= 0 {
+		delete(d.pending, key)
+	}
+}
+
+// updates are ordered per user, the chat is used for the updates without the sender
+func updateKey(upd tgbotapi.Update) int64 {
+	switch {
+	case upd.CallbackQuery != nil && upd.CallbackQuery.From != nil:
+		return int64(upd.CallbackQuery.From.ID)
+	case upd.Message != nil && upd.Message.From != nil:
+		return int64(upd.Message.From.ID)
+	case upd.Message != nil && upd.Message.Chat != nil:
+		return upd.Message.Chat.ID
+	default:
+		return 0
+	}
+}
+
+func shardMetric(idx int) string {
+	return "shard_" + strconv.Itoa(idx) + "_depth"
+}
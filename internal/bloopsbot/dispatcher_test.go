@@ -0,0 +1,106 @@
+package bloopsbot
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+func testUpdate(userID, messageID int) tgbotapi.Update {
+	return tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID: messageID,
+		From:      &tgbotapi.User{ID: userID},
+		Chat:      &tgbotapi.Chat{ID: int64(userID)},
+	}}
+}
+
+func TestDispatcherOrder(t *testing.T) {
+	t.Parallel()
+
+	const users, perUser = 5, 50
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mtx sync.Mutex
+	handled := map[int][]int{}
+	wg := &sync.WaitGroup{}
+	wg.Add(users * perUser)
+
+	d := newDispatcher(3, 0, func(_ context.Context, upd tgbotapi.Update) {
+		mtx.Lock()
+		handled[upd.Message.From.ID] = append(handled[upd.Message.From.ID], upd.Message.MessageID)
+		mtx.Unlock()
+		wg.Done()
+	}, nil)
+
+	updates := make(chan tgbotapi.Update)
+	go d.run(ctx, updates)
+	for i := 0; i < perUser; i++ {
+		for u := 1; u <= users; u++ {
+			updates <- testUpdate(u, i)
+		}
+	}
+
+	wg.Wait()
+	for u := 1; u <= users; u++ {
+		for i, messageID := range handled[u] {
+			if messageID != i {
+				t.Fatalf("user %d: updates out of order %v", u, handled[u])
+			}
+		}
+	}
+}
+
+func TestDispatcherUserQueue(t *testing.T) {
+	t.Parallel()
+
+	d := newDispatcher(1, 2, nil, nil)
+	if !d.acquire(1) || !d.acquire(1) {
+		t.Fatalf("expected two pending updates to be accepted")
+	}
+
+	if d.acquire(1) {
+		t.Errorf("expected the third pending update to be dropped")
+	}
+
+	if !d.acquire(2) {
+		t.Errorf("expected another user not to be affected")
+	}
+
+	d.release(1)
+	if !d.acquire(1) {
+		t.Errorf("expected the update to be accepted after the release")
+	}
+}
+
+func TestDispatcherDrop(t *testing.T) {
+	t.Parallel()
+
+	var dropped []tgbotapi.Update
+	// no workers, the updates stay queued
+	d := newDispatcher(1, 2, nil, func(_ context.Context, upd tgbotapi.Update) {
+		dropped = append(dropped, upd)
+	})
+
+	for i := 0; i < 3; i++ {
+		d.dispatch(context.Background(), testUpdate(1, i))
+	}
+
+	query := tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{ID: "stop", From: &tgbotapi.User{ID: 1}}}
+	d.dispatch(context.Background(), query)
+	for i := 0; i < 3; i++ {
+		d.dispatch(context.Background(), testUpdate(2, i))
+	}
+
+	if len(dropped) != 3 || dropped[1].CallbackQuery == nil || dropped[2].Message.From.ID != 2 {
+		t.Fatalf("expected the updates over the user limit to be dropped, got %d", len(dropped))
+	}
+
+	// the other user of the same shard is not affected
+	if n := d.shards[0].len(); n != 4 {
+		t.Errorf("expected two queued updates of each user, got %d", n)
+	}
+}
@@ -0,0 +1,306 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	feedbackModel "github.com/bloops-games/bloops/internal/database/feedback/model"
+	userDb "github.com/bloops-games/bloops/internal/database/user/database"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/logging"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	inboxPageSize        = 5
+	inboxPageDataPrefix  = "inbox:page:"
+	inboxOpenDataPrefix  = "inbox:open:"
+	inboxReplyDataPrefix = "inbox:reply:"
+)
+
+func (m *manager) handleFeedbackCommand(u userModel.User, chatID int64) error {
+	msg := tgbotapi.NewMessage(chatID, resource.TextFeedbackMsg)
+	msg.ReplyMarkup = resource.CommonButtons
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(text string) error {
+		return m.sendFeedback(u, chatID, text)
+	})
+
+	return nil
+}
+
+// sendFeedback /feedback text
+func (m *manager) sendFeedback(u userModel.User, chatID int64, text string) error {
+	m.deleteCommandCbHandler(u.ID)
+
+	if _, err := m.feedbackDB.Add(feedbackModel.NewFeedback(u.ID, chatID, text)); err != nil {
+		return fmt.Errorf("feedback db add: %w", err)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextFeedbackThanksMsg)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	// the feedback is already stored, the admin can always find it in the inbox
+	if m.config.Admin != "" {
+		logger := logging.DefaultLogger().Named("bloopsbot.manager.sendFeedback")
+		admin, err := m.userDB.FetchByUsername(m.config.Admin)
+		if err != nil {
+			if !errors.Is(err, userDb.ErrNotFound) {
+				logger.Errorf("fetch by username: %v", err)
+			}
+
+			return nil
+		}
+
+		if _, err := m.tg.Send(tgbotapi.NewMessage(admin.ID, resource.TextFeedbackNewMsg)); err != nil {
+			logger.Errorf("send msg: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) handleInboxCommand(u userModel.User, chatID int64) error {
+	text, markup, err := m.renderInboxPage(0)
+	if err != nil {
+		return fmt.Errorf("render inbox page: %w", err)
+	}
+
+	// the feedback is arbitrary user text, so it is sent without markup parsing
+	msg := tgbotapi.NewMessage(chatID, text)
+	if markup != nil {
+		msg.ReplyMarkup = markup
+	}
+
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		return m.handleInboxQuery(u, query)
+	})
+
+	return nil
+}
+
+func (m *manager) handleInboxQuery(u userModel.User, query *tgbotapi.CallbackQuery) error {
+	if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	chatID, messageID := query.Message.Chat.ID, query.Message.MessageID
+
+	var text string
+	var markup *tgbotapi.InlineKeyboardMarkup
+	switch {
+	case strings.HasPrefix(query.Data, inboxPageDataPrefix):
+		page, err := strconv.Atoi(strings.TrimPrefix(query.Data, inboxPageDataPrefix))
+		if err != nil {
+			return fmt.Errorf("strconv: %w", err)
+		}
+
+		if text, markup, err = m.renderInboxPage(page); err != nil {
+			return fmt.Errorf("render inbox page: %w", err)
+		}
+	case strings.HasPrefix(query.Data, inboxOpenDataPrefix):
+		feedback, err := m.fetchFeedback(strings.TrimPrefix(query.Data, inboxOpenDataPrefix))
+		if err != nil {
+			return fmt.Errorf("fetch feedback: %w", err)
+		}
+
+		if feedback.Status == feedbackModel.StatusNew {
+			feedback.Status = feedbackModel.StatusRead
+			if err := m.feedbackDB.Store(feedback); err != nil {
+				return fmt.Errorf("feedback db store: %w", err)
+			}
+		}
+
+		text, markup = renderFeedback(feedback)
+	case strings.HasPrefix(query.Data, inboxReplyDataPrefix):
+		feedback, err := m.fetchFeedback(strings.TrimPrefix(query.Data, inboxReplyDataPrefix))
+		if err != nil {
+			return fmt.Errorf("fetch feedback: %w", err)
+		}
+
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextInboxReplyMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		m.registerCommandCbHandler(u.ID, func(answer string) error {
+			return m.replyFeedback(u, chatID, feedback, answer)
+		})
+
+		return nil
+	default:
+		return nil
+	}
+
+	msg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	msg.ReplyMarkup = markup
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) replyFeedback(u userModel.User, chatID int64, feedback feedbackModel.Feedback, answer string) error {
+	m.deleteCommandCbHandler(u.ID)
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(
+		feedback.ChatID,
+		fmt.Sprintf(resource.TextInboxAnswerMsg, answer),
+	)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	feedback.Status = feedbackModel.StatusAnswered
+	feedback.Answer = answer
+	feedback.AnsweredAt = time.Now()
+	if err := m.feedbackDB.Store(feedback); err != nil {
+		return fmt.Errorf("feedback db store: %w", err)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextInboxRepliedMsg)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) fetchFeedback(id string) (feedbackModel.Feedback, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return feedbackModel.Feedback{}, fmt.Errorf("strconv: %w", err)
+	}
+
+	feedback, err := m.feedbackDB.Fetch(n)
+	if err != nil {
+		return feedback, fmt.Errorf("feedback db fetch: %w", err)
+	}
+
+	return feedback, nil
+}
+
+func (m *manager) renderInboxPage(page int) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	list, total, err := m.feedbackDB.FetchPage(page*inboxPageSize, inboxPageSize)
+	if err != nil {
+		return "", nil, fmt.Errorf("feedback db fetch page: %w", err)
+	}
+
+	if total == 0 {
+		return resource.TextInboxEmptyMsg, nil, nil
+	}
+
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	pages := (total + inboxPageSize - 1) / inboxPageSize
+	_, _ = fmt.Fprintf(buf, "%s Отзывы %d/%d\n\n", emoji.Envelope.String(), page+1, pages)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, feedback := range list {
+		id := strconv.FormatUint(feedback.ID, 10)
+		_, _ = fmt.Fprintf(
+			buf,
+			"%s #%s %s\n",
+			renderFeedbackStatus(feedback.Status),
+			id,
+			feedback.CreatedAt.Format(banTimeLayout),
+		)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				renderFeedbackStatus(feedback.Status)+" #"+id+" "+truncate(feedback.Text, 32),
+				inboxOpenDataPrefix+id,
+			),
+		))
+	}
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(
+			resource.TextInboxPrev,
+			inboxPageDataPrefix+strconv.Itoa(page-1),
+		))
+	}
+
+	if page+1 < pages {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(
+			resource.TextInboxNext,
+			inboxPageDataPrefix+strconv.Itoa(page+1),
+		))
+	}
+
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return buf.String(), &markup, nil
+}
+
+func renderFeedback(feedback feedbackModel.Feedback) (string, *tgbotapi.InlineKeyboardMarkup) {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	_, _ = fmt.Fprintf(
+		buf,
+		"%s Отзыв #%d %s\n\n",
+		renderFeedbackStatus(feedback.Status),
+		feedback.ID,
+		feedback.CreatedAt.Format(banTimeLayout),
+	)
+	_, _ = fmt.Fprintf(buf, "%s\n", feedback.Text)
+	if feedback.Status == feedbackModel.StatusAnswered {
+		_, _ = fmt.Fprintf(buf, "\nОтвет:\n%s\n", feedback.Answer)
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(resource.TextInboxBack, inboxPageDataPrefix+"0"),
+		tgbotapi.NewInlineKeyboardButtonData(
+			resource.TextInboxReply,
+			inboxReplyDataPrefix+strconv.FormatUint(feedback.ID, 10),
+		),
+	))
+
+	return buf.String(), &markup
+}
+
+func renderFeedbackStatus(status feedbackModel.Status) string {
+	switch status {
+	case feedbackModel.StatusRead:
+		return emoji.OpenMailboxWithRaisedFlag.String()
+	case feedbackModel.StatusAnswered:
+		return emoji.CheckMarkButton.String()
+	default:
+		return emoji.NewButton.String()
+	}
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,82 @@
+package bloopsbot
+
+import (
+	"sync"
+	"time"
+)
+
+// buckets of the users who have not written for a while are forgotten once there are more than this
+const floodBucketsLimit = 4096
+
+// the token bucket per user, rate updates per second with bursts up to burst updates
+func newFloodLimiter(rate float64, burst int) *floodLimiter {
+	return &floodLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[int64]*floodBucket{},
+		now:     time.Now,
+	}
+}
+
+type floodLimiter struct {
+	rate  float64
+	burst float64
+
+	mtx     sync.Mutex
+	buckets map[int64]*floodBucket
+	now     func() time.Time
+}
+
+type floodBucket struct {
+	tokens  float64
+	updated time.Time
+	// the user has been told to slow down since the bucket was emptied
+	warned bool
+}
+
+// allow reports whether the update of the user is accepted and whether the user has to be warned about the flood
+func (l *floodLimiter) allow(key int64) (allowed, warn bool) {
+	if l.rate <= 0 {
+		return true, false
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= floodBucketsLimit {
+			l.prune(now)
+		}
+
+		b = &floodBucket{tokens: l.burst, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.updated).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.updated = now
+
+	if b.tokens < 1 {
+		warn = !b.warned
+		b.warned = true
+		return false, warn
+	}
+
+	b.tokens--
+	b.warned = false
+
+	return true, false
+}
+
+// prune the buckets that are full again, their users are not flooding
+func (l *floodLimiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
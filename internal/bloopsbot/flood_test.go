@@ -0,0 +1,37 @@
+package bloopsbot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFloodLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newFloodLimiter(1, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.allow(1); !ok {
+			t.Fatalf("expected burst update %d to be allowed", i)
+		}
+	}
+
+	if ok, warn := l.allow(1); ok || !warn {
+		t.Errorf("expected the flood to be stopped with a warning, got allowed=%t warn=%t", ok, warn)
+	}
+
+	if ok, warn := l.allow(1); ok || warn {
+		t.Errorf("expected the flood to be stopped silently, got allowed=%t warn=%t", ok, warn)
+	}
+
+	if ok, _ := l.allow(2); !ok {
+		t.Errorf("expected another user not to be limited")
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := l.allow(1); !ok {
+		t.Errorf("expected the update to be allowed after the refill")
+	}
+}
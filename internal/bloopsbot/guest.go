@@ -0,0 +1,307 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	guestDb "github.com/bloops-games/bloops/internal/database/guest/database"
+	guestModel "github.com/bloops-games/bloops/internal/database/guest/model"
+	matchstateModel "github.com/bloops-games/bloops/internal/database/matchstate/model"
+	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	guestAddDataPrefix    = "guest:add:"
+	guestRemoveDataPrefix = "guest:remove:"
+	guestDeleteDataPrefix = "guest:delete:"
+)
+
+// addGuest the saved guest of the owner with the same name is reused, so its stat goes on
+func (m *manager) addGuest(session *match.Session, chatID int64, owner userModel.User, name string) error {
+	guest, err := m.guestDB.FetchOrAdd(guestModel.NewGuest(owner.ID, name))
+	if err != nil {
+		return fmt.Errorf("guest db fetch or add: %w", err)
+	}
+
+	if err := session.AddPlayer(matchstateModel.NewGuestPlayer(chatID, owner, guest.ID, guest.Name)); err != nil {
+		return fmt.Errorf("add player: %w", err)
+	}
+
+	return nil
+}
+
+// sendSavedGuests the saved guests of the owner are added in one click
+func (m *manager) sendSavedGuests(u userModel.User, chatID int64) error {
+	guests, err := m.guestDB.FetchByOwnerID(u.ID)
+	if err != nil && !errors.Is(err, guestDb.ErrNotFound) {
+		return fmt.Errorf("fetch guests: %w", err)
+	}
+
+	if len(guests) == 0 {
+		return nil
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	for _, guest := range guests {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(guest.Name, guestAddDataPrefix+strconv.FormatInt(guest.ID, 10)),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, resource.TextGuestsSavedMsg)
+	msg.ReplyMarkup = markup
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		id, err := strconv.ParseInt(strings.TrimPrefix(query.Data, guestAddDataPrefix), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse guest id: %w", err)
+		}
+
+		guest, err := m.guestDB.Fetch(id)
+		if err != nil {
+			return fmt.Errorf("fetch guest: %w", err)
+		}
+
+		return m.addOfflinePlayer(u, chatID, guest.Name)
+	})
+
+	return nil
+}
+
+func (m *manager) handleRemoveGuestCmd(u userModel.User, chatID int64) error {
+	session, ok := m.userMatchSession(u.ID)
+	if !ok {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFound)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	guests := session.Guests(u.ID)
+	if len(guests) == 0 {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextGuestsNoPlayingMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	for _, guest := range guests {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				emoji.CrossMark.String()+" "+guest.User.FirstName,
+				guestRemoveDataPrefix+strconv.FormatInt(guest.UserID, 10),
+			),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, resource.TextGuestRemoveMsg)
+	msg.ReplyMarkup = markup
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		m.deleteQueryCbHandler(chatID, output.MessageID)
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		id, err := strconv.ParseInt(strings.TrimPrefix(query.Data, guestRemoveDataPrefix), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse guest id: %w", err)
+		}
+
+		return m.removeGuest(u, chatID, session, id)
+	})
+
+	return nil
+}
+
+// removeGuestByName /remove Grandma
+func (m *manager) removeGuestByName(u userModel.User, chatID int64, name string) error {
+	session, ok := m.userMatchSession(u.ID)
+	if !ok {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextGameRoomNotFound)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	name = strings.TrimSpace(name)
+	if guest, ok := session.FindGuest(u.ID, name); ok {
+		return m.removeGuest(u, chatID, session, guest.UserID)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextGuestNotFoundMsg, name))); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+// removeGuest only the owner of the guest can remove it
+func (m *manager) removeGuest(u userModel.User, chatID int64, session *match.Session, guestID int64) error {
+	var name string
+	if guest, err := m.guestDB.Fetch(guestID); err == nil {
+		name = guest.Name
+	}
+
+	text := fmt.Sprintf(resource.TextGuestRemovedMsg, name)
+	if err := session.RemoveGuest(u.ID, guestID); err != nil {
+		switch {
+		case errors.Is(err, match.ErrNotGuestOwner):
+			text = resource.TextGuestNotOwnerMsg
+		case errors.Is(err, match.ErrPlayerNotFound):
+			text = fmt.Sprintf(resource.TextGuestNotFoundMsg, name)
+		default:
+			return fmt.Errorf("remove guest: %w", err)
+		}
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) handleGuestsCmd(u userModel.User, chatID int64) error {
+	text, markup, ok, err := m.renderGuests(u.ID)
+	if err != nil {
+		return fmt.Errorf("render guests: %w", err)
+	}
+
+	if !ok {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextGuestsEmptyMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		return m.handleGuestDeleteQuery(u, chatID, output.MessageID, query)
+	})
+
+	return nil
+}
+
+// handleGuestDeleteQuery the profile of the guest is deleted, the stat is kept
+func (m *manager) handleGuestDeleteQuery(u userModel.User, chatID int64, messageID int, query *tgbotapi.CallbackQuery) error {
+	id, err := strconv.ParseInt(strings.TrimPrefix(query.Data, guestDeleteDataPrefix), 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse guest id: %w", err)
+	}
+
+	guest, err := m.guestDB.Fetch(id)
+	if err != nil && !errors.Is(err, guestDb.ErrNotFound) {
+		return fmt.Errorf("fetch guest: %w", err)
+	}
+
+	if err == nil && guest.OwnerID == u.ID {
+		if err := m.guestDB.Delete(id); err != nil && !errors.Is(err, guestDb.ErrNotFound) {
+			return fmt.Errorf("guest db delete: %w", err)
+		}
+	}
+
+	if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, fmt.Sprintf(resource.TextGuestDeletedMsg, guest.Name))); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	text, markup, ok, err := m.renderGuests(u.ID)
+	if err != nil {
+		return fmt.Errorf("render guests: %w", err)
+	}
+
+	if !ok {
+		m.deleteQueryCbHandler(chatID, messageID)
+		if _, err := m.tg.Send(tgbotapi.NewEditMessageText(chatID, messageID, resource.TextGuestsEmptyMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ReplyMarkup = &markup
+	if _, err := m.tg.Send(edit); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+// renderGuests one line of stat and one delete button per guest
+func (m *manager) renderGuests(ownerID int64) (string, tgbotapi.InlineKeyboardMarkup, bool, error) {
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	guests, err := m.guestDB.FetchByOwnerID(ownerID)
+	if err != nil {
+		if errors.Is(err, guestDb.ErrNotFound) {
+			return "", markup, false, nil
+		}
+
+		return "", markup, false, fmt.Errorf("fetch guests: %w", err)
+	}
+
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	buf.WriteString(resource.TextGuestsMsg)
+	for _, guest := range guests {
+		stat, err := m.statDB.FetchProfileStat(guest.ID)
+		if err != nil && !errors.Is(err, statDb.ErrNotFound) {
+			return "", markup, false, fmt.Errorf("fetch profile stat: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(
+			buf,
+			resource.TextGuestStatMsg,
+			emoji.Alien.String(),
+			guest.Name,
+			stat.Count,
+			stat.Stars,
+			stat.BestPoints,
+		)
+
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				emoji.Wastebasket.String()+" "+guest.Name,
+				guestDeleteDataPrefix+strconv.FormatInt(guest.ID, 10),
+			),
+		))
+	}
+
+	return buf.String(), markup, len(guests) > 0, nil
+}
@@ -0,0 +1,334 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
+	statModel "github.com/bloops-games/bloops/internal/database/stat/model"
+	userDb "github.com/bloops-games/bloops/internal/database/user/database"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	historyPageSize = 5
+	// the older games are left to the profile
+	historyLimit = 50
+
+	historyPageDataPrefix   = "history:p:"
+	historyDetailDataPrefix = "history:d:"
+)
+
+// fetchHistory the latest games of the user first
+func (m *manager) fetchHistory(userID int64) ([]statModel.Stat, error) {
+	stats, err := m.statDB.FetchByuserID(userID)
+	if err != nil && !errors.Is(err, statDb.ErrNotFound) {
+		return nil, fmt.Errorf("fetch by user id: %w", err)
+	}
+
+	// the cached slice is shared, so it is sorted in a copy
+	history := make([]statModel.Stat, len(stats))
+	copy(history, stats)
+	statModel.SortByDate(history)
+	if len(history) > historyLimit {
+		history = history[:historyLimit]
+	}
+
+	return history, nil
+}
+
+// handleHistoryCmd one message with the pages of the games and the detail view of each game
+func (m *manager) handleHistoryCmd(u userModel.User, chatID int64) error {
+	history, err := m.fetchHistory(u.ID)
+	if err != nil {
+		return fmt.Errorf("fetch history: %w", err)
+	}
+
+	if len(history) == 0 {
+		return m.sendText(chatID, resource.TextHistoryEmptyMsg)
+	}
+
+	text, markup := renderHistoryPage(history, 0)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		return m.handleHistoryQuery(u, chatID, output.MessageID, query)
+	})
+
+	return nil
+}
+
+func (m *manager) handleHistoryQuery(u userModel.User, chatID int64, messageID int, query *tgbotapi.CallbackQuery) error {
+	if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		return fmt.Errorf("send answer msg: %w", err)
+	}
+
+	history, err := m.fetchHistory(u.ID)
+	if err != nil {
+		return fmt.Errorf("fetch history: %w", err)
+	}
+
+	text, markup, ok, err := renderHistoryQuery(history, query.Data)
+	if err != nil {
+		return fmt.Errorf("render history query: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ReplyMarkup = &markup
+	if _, err := m.tg.Send(edit); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+// renderHistoryQuery the page or the detail view of the button, false for the data of the other buttons
+func renderHistoryQuery(history []statModel.Stat, data string) (string, tgbotapi.InlineKeyboardMarkup, bool, error) {
+	var (
+		text   string
+		markup tgbotapi.InlineKeyboardMarkup
+	)
+
+	switch {
+	case strings.HasPrefix(data, historyPageDataPrefix):
+		page, err := strconv.Atoi(strings.TrimPrefix(data, historyPageDataPrefix))
+		if err != nil {
+			return "", markup, false, fmt.Errorf("parse page: %w", err)
+		}
+
+		text, markup = renderHistoryPage(history, page)
+	case strings.HasPrefix(data, historyDetailDataPrefix):
+		// page:stat id, the page to return to
+		parts := strings.SplitN(strings.TrimPrefix(data, historyDetailDataPrefix), ":", 2)
+		if len(parts) != 2 {
+			return "", markup, false, fmt.Errorf("invalid data %q", data)
+		}
+
+		page, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return "", markup, false, fmt.Errorf("parse page: %w", err)
+		}
+
+		text = resource.TextHistoryNotFoundMsg
+		for _, stat := range history {
+			if stat.ID.String() == parts[1] {
+				text = renderHistoryDetail(stat)
+				break
+			}
+		}
+
+		markup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(resource.TextHistoryBack, historyPageDataPrefix+strconv.Itoa(page)),
+		))
+	default:
+		return "", markup, false, nil
+	}
+
+	return text, markup, true, nil
+
+}
+
+// handleVsCmd asks for the username of the opponent
+func (m *manager) handleVsCmd(u userModel.User, chatID int64) error {
+	if err := m.sendText(chatID, resource.TextVsMsg); err != nil {
+		return err
+	}
+
+	m.registerCommandCbHandler(u.ID, func(username string) error {
+		return m.sendHeadToHead(u, chatID, username)
+	})
+
+	return nil
+}
+
+// sendHeadToHead /vs @username, the games are found in the stats of the user
+func (m *manager) sendHeadToHead(u userModel.User, chatID int64, username string) error {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+	opponent, err := m.userDB.FetchByUsername(username)
+	if err != nil {
+		if errors.Is(err, userDb.ErrNotFound) {
+			return m.sendText(chatID, resource.TextProfileCmdUserNotFound)
+		}
+
+		return fmt.Errorf("fetch by username: %w", err)
+	}
+
+	m.deleteCommandCbHandler(u.ID)
+	if opponent.ID == u.ID {
+		return m.sendText(chatID, resource.TextVsSelfMsg)
+	}
+
+	stats, err := m.statDB.FetchByuserID(u.ID)
+	if err != nil && !errors.Is(err, statDb.ErrNotFound) {
+		return fmt.Errorf("fetch by user id: %w", err)
+	}
+
+	h := statModel.NewHeadToHead(stats, opponent.ID)
+	if h.Games == 0 {
+		return m.sendText(chatID, fmt.Sprintf(resource.TextVsNoGamesMsg, opponent.FirstName))
+	}
+
+	text := fmt.Sprintf(resource.TextVsHeader, opponent.FirstName) +
+		fmt.Sprintf(resource.TextVsResult, h.Games, h.Wins, h.Losses, h.Draws, h.Points, h.OpponentPoints)
+
+	return m.sendText(chatID, text)
+}
+
+// renderHistoryPage the page is clamped, the games could be added since the previous click
+func renderHistoryPage(history []statModel.Stat, page int) (string, tgbotapi.InlineKeyboardMarkup) {
+	pages := (len(history) + historyPageSize - 1) / historyPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+
+	if page < 0 {
+		page = 0
+	}
+
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	_, _ = fmt.Fprintf(buf, resource.TextHistoryMsg, page+1, pages)
+
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	var details []tgbotapi.InlineKeyboardButton
+	for i := page * historyPageSize; i < len(history) && i < (page+1)*historyPageSize; i++ {
+		stat := history[i]
+		_, _ = fmt.Fprintf(
+			buf,
+			resource.TextHistoryLine,
+			i+1,
+			stat.CreatedAt.Format("02.01.2006"),
+			stat.MatchCode,
+			renderHistoryPlace(stat),
+			stat.SumPoints,
+			len(stat.Bloops),
+		)
+
+		if len(stat.CoPlayers) > 0 {
+			names := make([]string, len(stat.CoPlayers))
+			for j, p := range stat.CoPlayers {
+				names[j] = p.Name
+			}
+
+			_, _ = fmt.Fprintf(buf, resource.TextHistoryPlayers, strings.Join(names, ", "))
+		}
+
+		buf.WriteString("\n")
+		details = append(details, tgbotapi.NewInlineKeyboardButtonData(
+			strconv.Itoa(i+1),
+			fmt.Sprintf("%s%d:%s", historyDetailDataPrefix, page, stat.ID.String()),
+		))
+	}
+
+	markup.InlineKeyboard = append(markup.InlineKeyboard, details)
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(resource.TextHistoryPrev, historyPageDataPrefix+strconv.Itoa(page-1)))
+	}
+
+	if page < pages-1 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(resource.TextHistoryNext, historyPageDataPrefix+strconv.Itoa(page+1)))
+	}
+
+	if len(nav) > 0 {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, nav)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), markup
+}
+
+// renderHistoryPlace the games played before the history know only the winners
+func renderHistoryPlace(stat statModel.Stat) string {
+	switch {
+	case stat.Place == 1:
+		return emoji.FirstPlaceMedal.String() + " " + fmt.Sprintf(resource.TextHistoryPlace, stat.Place, stat.PlayersNum)
+	case stat.Place > 0:
+		return fmt.Sprintf(resource.TextHistoryPlace, stat.Place, stat.PlayersNum)
+	case stat.Conclusion == statModel.StatusFavorite:
+		return emoji.FirstPlaceMedal.String() + " " + resource.TextHistoryWin
+	default:
+		return resource.TextHistoryPart
+	}
+}
+
+func renderHistoryDetail(stat statModel.Stat) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	_, _ = fmt.Fprintf(buf, resource.TextHistoryDetailMsg, stat.MatchCode, stat.CreatedAt.Format("02.01.2006 15:04"))
+	_, _ = fmt.Fprintf(buf, "%s %s\n", emoji.Trophy.String(), renderHistoryPlace(stat))
+	_, _ = fmt.Fprintf(
+		buf,
+		"%s Очки: %d, лучший раунд %d, худший %d\n",
+		emoji.HundredPoints.String(),
+		stat.SumPoints,
+		stat.BestPoints,
+		stat.WorstPoints,
+	)
+	_, _ = fmt.Fprintf(buf, "%s Раундов: %d, категории: %s\n", emoji.VideoGame.String(), stat.RoundsNum, strings.Join(stat.Categories, ", "))
+	_, _ = fmt.Fprintf(
+		buf,
+		"%s Среднее время раунда: %s\n",
+		emoji.Stopwatch.String(),
+		stat.AverageDuration.Round(100*time.Millisecond).String(),
+	)
+
+	if len(stat.Bloops) > 0 {
+		_, _ = fmt.Fprintf(
+			buf,
+			"%s Блюпсы: %s, выполнено %d\n",
+			emoji.GemStone.String(),
+			strings.Join(stat.Bloops, ", "),
+			stat.CompletedBloops,
+		)
+	}
+
+	if stat.CorrectedRounds > 0 {
+		_, _ = fmt.Fprintf(buf, "%s Исправлено ведущим раундов: %d\n", emoji.Pencil.String(), stat.CorrectedRounds)
+	}
+
+	if len(stat.CoPlayers) > 0 {
+		players := append([]statModel.CoPlayer{{
+			UserID: stat.UserID,
+			Name:   resource.TextHistoryYou,
+			Points: stat.SumPoints,
+			Place:  stat.Place,
+		}}, stat.CoPlayers...)
+		sort.SliceStable(players, func(i, j int) bool {
+			return players[i].Place < players[j].Place
+		})
+
+		_, _ = fmt.Fprintf(buf, "\n%s Игроки\n", emoji.BustsInSilhouette.String())
+		for _, p := range players {
+			_, _ = fmt.Fprintf(buf, "%d. %s - %d\n", p.Place, p.Name, p.Points)
+		}
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,50 @@
+package bloopsbot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	statModel "github.com/bloops-games/bloops/internal/database/stat/model"
+	"github.com/google/uuid"
+)
+
+func TestRenderHistoryQuery(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+	history := make([]statModel.Stat, 7)
+	for i := range history {
+		history[i] = statModel.Stat{
+			ID:        uuid.New(),
+			MatchCode: int64(1000 + i),
+			CreatedAt: createdAt.Add(-time.Duration(i) * time.Hour),
+		}
+	}
+
+	_, markup := renderHistoryPage(history, 1)
+	details := markup.InlineKeyboard[0]
+	if len(details) != 2 {
+		t.Fatalf("expected the detail buttons of the second page, got %d", len(details))
+	}
+
+	for i, button := range details {
+		stat := history[historyPageSize+i]
+		text, markup, ok, err := renderHistoryQuery(history, *button.CallbackData)
+		if err != nil || !ok {
+			t.Fatalf("render detail %d: %v", i, err)
+		}
+
+		if !strings.Contains(text, renderHistoryDetail(stat)) {
+			t.Errorf("expected the detail of the game %d, got %q", stat.MatchCode, text)
+		}
+
+		if back := *markup.InlineKeyboard[0][0].CallbackData; back != historyPageDataPrefix+"1" {
+			t.Errorf("expected to return to the second page, got %q", back)
+		}
+	}
+
+	if _, _, ok, err := renderHistoryQuery(history, "fix:p:1"); ok || err != nil {
+		t.Errorf("expected the other buttons to be skipped, got %v %v", ok, err)
+	}
+}
@@ -12,25 +12,42 @@ import (
 	"time"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/builder"
+	"github.com/bloops-games/bloops/internal/bloopsbot/card"
 	"github.com/bloops-games/bloops/internal/bloopsbot/match"
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
 	"github.com/bloops-games/bloops/internal/bloopsbot/util"
+	achievementDb "github.com/bloops-games/bloops/internal/database/achievement/database"
+	builderStateDb "github.com/bloops-games/bloops/internal/database/builderstate/database"
+	builderStateModel "github.com/bloops-games/bloops/internal/database/builderstate/model"
+	feedbackDb "github.com/bloops-games/bloops/internal/database/feedback/database"
+	guestDb "github.com/bloops-games/bloops/internal/database/guest/database"
 	stateDB "github.com/bloops-games/bloops/internal/database/matchstate/database"
 	matchstateModel "github.com/bloops-games/bloops/internal/database/matchstate/model"
+	moderationDb "github.com/bloops-games/bloops/internal/database/moderation/database"
+	presetDb "github.com/bloops-games/bloops/internal/database/preset/database"
 	statDb "github.com/bloops-games/bloops/internal/database/stat/database"
 	statModel "github.com/bloops-games/bloops/internal/database/stat/model"
 	userDb "github.com/bloops-games/bloops/internal/database/user/database"
 	userModel "github.com/bloops-games/bloops/internal/database/user/model"
 	"github.com/bloops-games/bloops/internal/logging"
+	"github.com/bloops-games/bloops/internal/webhook"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
 type (
-	commandCbHandlerFunc  = func(string) error
-	commandHandlerFunc    = func(userModel.User, int64) error
-	commandMiddlewareFunc = func(userModel.User, int64) (bool, error)
+	commandCbHandlerFunc   = func(string) error
+	commandHandlerFunc     = func(userModel.User, int64) error
+	commandArgsHandlerFunc = func(userModel.User, int64, string) error
+	commandMiddlewareFunc  = func(userModel.User, int64) (bool, error)
+	queryCbHandlerFunc     = func(context.Context, *tgbotapi.CallbackQuery) error
 )
 
+// inline message ids are unique only within a chat
+type queryCbKey struct {
+	chatID    int64
+	messageID int
+}
+
 var ErrTelegramResponseTypeNotFound = fmt.Errorf("telegram response not found")
 
 func NewManager(
@@ -39,6 +56,13 @@ func NewManager(
 	userDB *userDb.DB,
 	statDB *statDb.DB,
 	stateDB *stateDB.DB,
+	builderStateDB *builderStateDb.DB,
+	achievementDB *achievementDb.DB,
+	moderationDB *moderationDb.DB,
+	feedbackDB *feedbackDb.DB,
+	presetDB *presetDb.DB,
+	guestDB *guestDb.DB,
+	dispatcher *webhook.Dispatcher,
 ) *manager {
 	return &manager{
 		tg:                   tg,
@@ -46,20 +70,31 @@ func NewManager(
 		userBuildingSessions: map[int64]*builder.Session{},
 		userMatchSessions:    map[int64]*match.Session{},
 		matchSessions:        map[int64]*match.Session{},
-		commandCbHandlers:    map[int64]commandCbHandlerFunc{},
+		commandCbHandlers:    map[int64]commandCb{},
 		commandHandlers:      map[string]commandHandler{},
+		queryCbHandlers:      map[queryCbKey]queryCbHandlerFunc{},
+		flood:                newFloodLimiter(config.FloodRate, config.FloodBurst),
 		userDB:               userDB,
 		statDB:               statDB,
 		stateDB:              stateDB,
+		builderStateDB:       builderStateDB,
+		achievementDB:        achievementDB,
+		moderationDB:         moderationDB,
+		feedbackDB:           feedbackDB,
+		presetDB:             presetDB,
+		guestDB:              guestDB,
+		webhook:              dispatcher,
 	}
 }
 
 type commandHandler struct {
-	commandFn    commandHandlerFunc
+	commandFn commandHandlerFunc
+	// called instead of commandFn when the command has inline arguments
+	argsFn       commandArgsHandlerFunc
 	middlewareFn []commandMiddlewareFunc
 }
 
-func (t commandHandler) execute(u userModel.User, chatID int64) error {
+func (t commandHandler) execute(u userModel.User, chatID int64, args string) error {
 	for _, f := range t.middlewareFn {
 		ok, err := f(u, chatID)
 		if err != nil {
@@ -71,9 +106,19 @@ func (t commandHandler) execute(u userModel.User, chatID int64) error {
 		}
 	}
 
+	if args != "" && t.argsFn != nil {
+		return t.argsFn(u, chatID, args)
+	}
+
 	return t.commandFn(u, chatID)
 }
 
+// pending prompt of the conversational command
+type commandCb struct {
+	fn        commandCbHandlerFunc
+	expiresAt time.Time
+}
+
 type manager struct {
 	tg     *tgbotapi.BotAPI
 	config *Config
@@ -85,17 +130,32 @@ type manager struct {
 	userMatchSessions map[int64]*match.Session
 	// key: generated int64 code
 	matchSessions map[int64]*match.Session
-	// command callbacks
-	commandCbHandlers map[int64]commandCbHandlerFunc
+	// key: UserID, pending prompts of the commands
+	commandCbHandlers map[int64]commandCb
 	// command handlers
 	commandHandlers map[string]commandHandler
-
-	userDB     *userDb.DB
-	statDB     *statDb.DB
-	stateDB    *stateDB.DB
-	cancel     func()
-	ctxSess    context.Context
-	cancelSess func()
+	// inline buttons callbacks of the messages sent by the manager
+	queryCbHandlers map[queryCbKey]queryCbHandlerFunc
+	// running admin broadcast
+	broadcast *broadcast
+	// every update passes the middleware chain
+	pipeline requestHandlerFunc
+	flood    *floodLimiter
+
+	userDB         *userDb.DB
+	statDB         *statDb.DB
+	stateDB        *stateDB.DB
+	builderStateDB *builderStateDb.DB
+	achievementDB  *achievementDb.DB
+	moderationDB   *moderationDb.DB
+	feedbackDB     *feedbackDb.DB
+	presetDB       *presetDb.DB
+	guestDB        *guestDb.DB
+	cards          *card.Renderer
+	webhook        *webhook.Dispatcher
+	cancel         func()
+	ctxSess        context.Context
+	cancelSess     func()
 }
 
 func (m *manager) Stop() {
@@ -109,6 +169,13 @@ func (m *manager) Run(ctx context.Context) error {
 	m.cancel = cancel
 	m.ctxSess, m.cancelSess = context.WithCancel(context.Background())
 
+	cards, err := card.New()
+	if err != nil {
+		return fmt.Errorf("new card renderer: %w", err)
+	}
+
+	m.cards = cards
+
 	if m.config.BotWebhookHookURL != "" {
 		_, err := m.tg.SetWebhook(tgbotapi.NewWebhook(m.config.BotWebhookHookURL + m.config.BotToken))
 		if err != nil {
@@ -153,52 +220,116 @@ func (m *manager) Run(ctx context.Context) error {
 		updates = up
 	}
 
-	userMiddleware := []commandMiddlewareFunc{m.isActive}
+	m.pipeline = chainMiddleware(
+		m.serve,
+		recoverMiddleware,
+		logMiddleware,
+		m.floodMiddleware,
+		m.userMiddleware,
+		m.banMiddleware,
+	)
+
 	adminMiddleware := []commandMiddlewareFunc{m.isAdmin}
 	// register text command handlers
 	m.registerCommandHandler(
 		resource.CmdStart,
-		commandHandler{commandFn: m.handleStartCommand, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleStartCommand},
 	)
 	m.registerCommandHandler(
 		resource.CmdFeedback,
-		commandHandler{commandFn: m.handleFeedbackCommand, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleFeedbackCommand, argsFn: m.sendFeedback},
 	)
 	m.registerCommandHandler(
 		resource.CmdRules,
-		commandHandler{commandFn: m.handleRulesButton, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleRulesButton},
 	)
 	m.registerCommandHandler(
 		resource.CmdProfile,
-		commandHandler{commandFn: m.handleProfileCmd, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleProfileCmd, argsFn: m.sendUserProfile},
 	)
 	m.registerCommandHandler(
 		resource.ProfileButtonText,
-		commandHandler{commandFn: m.handleProfileButton, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleProfileButton},
 	)
 	m.registerCommandHandler(
 		resource.CreateButtonText,
-		commandHandler{commandFn: m.handleCreateButton, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleCreateButton},
 	)
 	m.registerCommandHandler(
 		resource.JoinButtonText,
-		commandHandler{commandFn: m.handleJoinButton, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleJoinButton},
+	)
+	m.registerCommandHandler(
+		resource.CmdJoin,
+		commandHandler{commandFn: m.handleJoinButton, argsFn: m.joinMatch},
+	)
+	m.registerCommandHandler(
+		resource.PresetsButtonText,
+		commandHandler{commandFn: m.handlePresetsCommand},
+	)
+	m.registerCommandHandler(
+		resource.CmdPresets,
+		commandHandler{commandFn: m.handlePresetsCommand, argsFn: m.playPreset},
 	)
 	m.registerCommandHandler(
 		resource.LeaveButtonText,
-		commandHandler{commandFn: m.handleButtonExit, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleButtonExit},
 	)
 	m.registerCommandHandler(
 		resource.RuleButtonText,
-		commandHandler{commandFn: m.handleRulesButton, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleRulesButton},
 	)
 	m.registerCommandHandler(
 		resource.CmdAddPlayer,
-		commandHandler{commandFn: m.handleRegisterOfflinePlayerCmd, middlewareFn: userMiddleware},
+		commandHandler{commandFn: m.handleRegisterOfflinePlayerCmd, argsFn: m.addOfflinePlayer},
+	)
+	m.registerCommandHandler(
+		resource.CmdRemove,
+		commandHandler{commandFn: m.handleRemoveGuestCmd, argsFn: m.removeGuestByName},
+	)
+	m.registerCommandHandler(
+		resource.CmdGuests,
+		commandHandler{commandFn: m.handleGuestsCmd},
+	)
+	m.registerCommandHandler(
+		resource.CmdCorrect,
+		commandHandler{commandFn: m.handleCorrectCmd, argsFn: m.correctByArgs},
+	)
+	m.registerCommandHandler(
+		resource.CmdHistory,
+		commandHandler{commandFn: m.handleHistoryCmd},
+	)
+	m.registerCommandHandler(
+		resource.CmdVs,
+		commandHandler{commandFn: m.handleVsCmd, argsFn: m.sendHeadToHead},
+	)
+	m.registerCommandHandler(
+		resource.CmdPresenter,
+		commandHandler{commandFn: m.handlePresenterCommand},
 	)
 	m.registerCommandHandler(
 		resource.CmdBan,
-		commandHandler{commandFn: m.handleBanCommand, middlewareFn: adminMiddleware},
+		commandHandler{commandFn: m.handleBanCommand, argsFn: m.banUser, middlewareFn: adminMiddleware},
+	)
+	m.registerCommandHandler(
+		resource.CmdUnban,
+		commandHandler{commandFn: m.handleUnbanCommand, argsFn: m.unbanUser, middlewareFn: adminMiddleware},
+	)
+	m.registerCommandHandler(
+		resource.CmdBanList,
+		commandHandler{commandFn: m.handleBanListCommand, middlewareFn: adminMiddleware},
+	)
+	m.registerCommandHandler(
+		resource.CmdBanLog,
+		commandHandler{commandFn: m.handleBanLogCommand, argsFn: m.sendBanLog, middlewareFn: adminMiddleware},
+	)
+	m.registerCommandHandler(
+		resource.CmdInbox,
+		commandHandler{commandFn: m.handleInboxCommand, middlewareFn: adminMiddleware},
+	)
+	m.registerCommandHandler(
+		resource.CmdBroadcast,
+		commandHandler{commandFn: m.handleBroadcastCommand, middlewareFn: adminMiddleware},
 	)
 
 	// restoreInterruptedGames not completed sessions
@@ -206,65 +337,91 @@ func (m *manager) Run(ctx context.Context) error {
 		return fmt.Errorf("restoreInterruptedGames: %w", err)
 	}
 
-	wg := &sync.WaitGroup{}
-	poolWorkerNum := runtime.NumCPU()
-	wg.Add(poolWorkerNum)
+	if err := m.restoreInterruptedBuilders(); err != nil {
+		return fmt.Errorf("restoreInterruptedBuilders: %w", err)
+	}
+
+	go m.webhook.Run(ctx)
 
-	for i := 0; i < poolWorkerNum; i++ {
-		go m.pool(ctx, wg, updates)
+	shardsNum := m.config.DispatcherShards
+	if shardsNum <= 0 {
+		shardsNum = runtime.NumCPU() * 4
 	}
 
-	wg.Wait()
-	m.shutdown()
+	newDispatcher(
+		shardsNum,
+		m.config.DispatcherUserQueueSize,
+		m.handleUpdate,
+		m.dropUpdate,
+	).run(ctx, updates)
+	if m.config.BotWebhookHookURL == "" {
+		m.tg.StopReceivingUpdates()
+	}
+
+	m.shutdown(ctx)
 	return nil
 }
 
-func (m *manager) pool(ctx context.Context, wg *sync.WaitGroup, updCh tgbotapi.UpdatesChannel) {
-	defer wg.Done()
-	logger := logging.FromContext(ctx).Named("manager.pool")
-	for {
-		select {
-		case update := <-updCh:
-			u, err := m.recvUser(update)
-			if err != nil {
-				logger.Errorf("recv user: %v", err)
-				continue
-			}
+func (m *manager) handleUpdate(ctx context.Context, update tgbotapi.Update) {
+	if err := m.pipeline(ctx, newRequest(update)); err != nil {
+		if !errors.Is(err, match.ErrValidation) {
+			logging.FromContext(ctx).Named("manager.handleUpdate").Errorf("handle update: %v", err)
+		}
+	}
+}
 
-			if update.Message != nil {
-				if update.Message.Chat.IsGroup() || update.Message.Chat.IsSuperGroup() {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID, resource.TextChatNotAllowed)
-					msg.ParseMode = tgbotapi.ModeMarkdown
-					if _, err := m.tg.Send(msg); err != nil {
-						logger.Errorf("send msg: %v", err)
-					}
-					continue
-				}
+// dropUpdate the dropped callback query is answered, otherwise the client keeps waiting for it
+func (m *manager) dropUpdate(ctx context.Context, update tgbotapi.Update) {
+	query := update.CallbackQuery
+	if query == nil {
+		return
+	}
 
-				if err := m.route(ctx, u, update); err != nil {
-					if !errors.Is(err, match.ErrValidation) {
-						logger.Errorf("handle command query: %v", err)
-					}
-				}
-			}
+	// the update reader does not wait for telegram
+	go func() {
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.TextFloodMsg)); err != nil {
+			logging.FromContext(ctx).Named("manager.dropUpdate").Errorf("send answer msg: %v", err)
+		}
+	}()
+}
 
-			if update.CallbackQuery != nil {
-				if err := m.handleCallbackQuery(ctx, u, update); err != nil {
-					logger.Errorf("handle commandCbHandler query: %v", err)
-				}
+// serve the update that has passed the middleware
+func (m *manager) serve(ctx context.Context, req *request) error {
+	update, u := req.update, req.user
+	if update.Message != nil {
+		if update.Message.Chat.IsGroup() || update.Message.Chat.IsSuperGroup() {
+			msg := tgbotapi.NewMessage(update.Message.Chat.ID, resource.TextChatNotAllowed)
+			msg.ParseMode = tgbotapi.ModeMarkdown
+			if _, err := m.tg.Send(msg); err != nil {
+				return fmt.Errorf("send msg: %w", err)
 			}
-		case <-ctx.Done():
-			return
+
+			return nil
+		}
+
+		if err := m.route(ctx, u, update); err != nil {
+			return fmt.Errorf("handle command query: %w", err)
+		}
+	}
+
+	if update.CallbackQuery != nil {
+		if err := m.handleCallbackQuery(ctx, u, update); err != nil {
+			return fmt.Errorf("handle commandCbHandler query: %w", err)
 		}
 	}
+
+	return nil
 }
 
 func (m *manager) route(ctx context.Context, u userModel.User, upd tgbotapi.Update) error {
 	logger := logging.FromContext(ctx).Named("bloopsbot.manager.route")
 	logger.Infof("Command received from user %s, command %s", u.FirstName, upd.Message.Text)
 
-	if handler, ok := m.commandHandler(upd.Message.Text); ok {
-		if err := handler.execute(u, upd.Message.Chat.ID); err != nil {
+	cmd, args := parseCommand(upd.Message.Text)
+	if handler, ok := m.commandHandler(cmd); ok {
+		// a new command cancels the pending prompt
+		m.deleteCommandCbHandler(u.ID)
+		if err := handler.execute(u, upd.Message.Chat.ID, args); err != nil {
 			return fmt.Errorf("execute command text handler: %w", err)
 		}
 
@@ -273,6 +430,7 @@ func (m *manager) route(ctx context.Context, u userModel.User, upd tgbotapi.Upda
 
 	if cb, ok := m.commandCbHandler(u.ID); ok {
 		if err := cb(upd.Message.Text); err != nil {
+			m.deleteCommandCbHandler(u.ID)
 			return fmt.Errorf("execute cb: %w", err)
 		}
 
@@ -307,6 +465,17 @@ func (m *manager) handleCallbackQuery(ctx context.Context, u userModel.User, upd
 		upd.CallbackQuery.Data,
 	)
 
+	query := upd.CallbackQuery
+	if query.Message != nil {
+		if cb, ok := m.queryCbHandler(query.Message.Chat.ID, query.Message.MessageID); ok {
+			if err := cb(ctx, query); err != nil {
+				return fmt.Errorf("execute query cb: %w", err)
+			}
+
+			return nil
+		}
+	}
+
 	if session, ok := m.userBuildingSession(u.ID); ok {
 		if err := session.Execute(upd); err != nil {
 			return fmt.Errorf("execute building cb: %w", err)
@@ -324,19 +493,28 @@ func (m *manager) handleCallbackQuery(ctx context.Context, u userModel.User, upd
 
 func (m *manager) buildGameConfig(session *builder.Session, code int64) match.Config {
 	config := match.Config{
-		Timeout:    m.config.PlayingTimeout,
-		Code:       code,
-		Tg:         m.tg,
-		DoneFn:     m.matchDoneFn,
-		WarnFn:     m.matchWarnFn,
-		AuthorID:   session.AuthorID,
-		AuthorName: session.AuthorName,
-		RoundsNum:  session.RoundsNum,
-		RoundTime:  session.RoundTime,
-		Bloopses:   []resource.Bloops{},
-		Categories: []string{},
-		Letters:    []string{},
-		Vote:       session.Vote,
+		Timeout:     m.config.PlayingTimeout,
+		Code:        code,
+		Tg:          m.tg,
+		DoneFn:      m.matchDoneFn,
+		WarnFn:      m.matchWarnFn,
+		FinishFn:    m.matchFinishFn,
+		EventFn:     m.emitEvent,
+		AuthorID:    session.AuthorID,
+		AuthorName:  session.AuthorName,
+		RoundsNum:   session.RoundsNum,
+		RoundTime:   session.RoundTime,
+		Bloopses:    []resource.Bloops{},
+		Categories:  []string{},
+		Letters:     []string{},
+		Vote:        session.Vote && !session.HotSeat,
+		VoteMode:    session.VoteMode,
+		VoteTime:    session.VoteTime,
+		Scoring:     session.Scoring,
+		HotSeat:     session.HotSeat,
+		LiveBoard:   session.LiveBoard,
+		TieBreakers: append([]match.TieBreaker{}, session.TieBreakers...),
+		SuddenDeath: session.SuddenDeath,
 	}
 
 	for _, category := range session.Categories {
@@ -364,6 +542,10 @@ func (m *manager) builderWarnFn(session *builder.Session) error {
 	defer m.mtx.Unlock()
 	delete(m.userBuildingSessions, session.AuthorID)
 
+	if err := m.builderStateDB.Add(session.Serialize()); err != nil {
+		return fmt.Errorf("builder state db add: %w", err)
+	}
+
 	return nil
 }
 
@@ -374,20 +556,31 @@ func (m *manager) builderDoneFn(session *builder.Session) error {
 		delete(m.userBuildingSessions, session.AuthorID)
 	}()
 
-	code, err := util.GenerateCodeHash()
+	if err := m.createMatch(session); err != nil {
+		return fmt.Errorf("create match: %w", err)
+	}
+
+	if err := m.offerPreset(session); err != nil {
+		return fmt.Errorf("offer preset: %w", err)
+	}
+
+	return nil
+}
+
+// createMatch the lobby with the settings of the builder
+func (m *manager) createMatch(session *builder.Session) error {
+	code, err := m.generateMatchCode()
 	if err != nil {
-		return fmt.Errorf("hash: %w", err)
+		return fmt.Errorf("generate match code: %w", err)
 	}
 
-	for {
-		if _, ok := m.matchSession(code); !ok {
-			session := match.NewSession(m.buildGameConfig(session, code))
-			session.Run(m.ctxSess)
-			m.mtx.Lock()
-			m.matchSessions[code] = session
-			m.mtx.Unlock()
-			break
+	matchSession := m.runMatch(m.buildGameConfig(session, code))
+	if session.HotSeat {
+		if err := m.startHotSeat(session.ChatID, session.AuthorID, session.PlayerNames, matchSession); err != nil {
+			return fmt.Errorf("start hot seat: %w", err)
 		}
+
+		return nil
 	}
 
 	msg := tgbotapi.NewMessage(session.ChatID, resource.TextCreationGameCompletedSuccessfulMsg)
@@ -410,7 +603,74 @@ func (m *manager) builderDoneFn(session *builder.Session) error {
 	return nil
 }
 
-func (m *manager) matchWarnFn(session *match.Session) error {
+func (m *manager) generateMatchCode() (int64, error) {
+	for {
+		code, err := util.GenerateCodeHash()
+		if err != nil {
+			return 0, fmt.Errorf("hash: %w", err)
+		}
+
+		if _, ok := m.matchSession(code); !ok {
+			return code, nil
+		}
+	}
+}
+
+// runMatch starts the lobby and announces it to the webhook subscribers
+func (m *manager) runMatch(config match.Config) *match.Session {
+	matchSession := match.NewSession(config)
+	matchSession.Run(m.ctxSess)
+	m.mtx.Lock()
+	m.matchSessions[config.Code] = matchSession
+	m.mtx.Unlock()
+
+	m.emitEvent(webhook.EventLobbyCreated, webhook.Lobby{
+		Code:       config.Code,
+		AuthorID:   config.AuthorID,
+		AuthorName: config.AuthorName,
+		RoundsNum:  config.RoundsNum,
+		RoundTime:  config.RoundTime,
+		Categories: config.Categories,
+	})
+
+	return matchSession
+}
+
+// startHotSeat registers the host and the players entered in the builder, there is no code to join
+func (m *manager) startHotSeat(chatID, authorID int64, names []string, matchSession *match.Session) error {
+	host, err := m.userDB.Fetch(authorID)
+	if err != nil {
+		return fmt.Errorf("fetch user: %w", err)
+	}
+
+	if err := matchSession.AddPlayer(matchstateModel.NewPlayer(chatID, host, false)); err != nil {
+		return fmt.Errorf("add player: %w", err)
+	}
+
+	for _, name := range names {
+		if err := m.addGuest(matchSession, chatID, host, name); err != nil {
+			return fmt.Errorf("add guest: %w", err)
+		}
+	}
+
+	m.mtx.Lock()
+	m.userMatchSessions[host.ID] = matchSession
+	m.mtx.Unlock()
+
+	msg := tgbotapi.NewMessage(chatID, resource.TextHotSeatReadyMsg)
+	msg.ParseMode = tgbotapi.ModeMarkdown
+	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(resource.StartButton, resource.LeaveButton, resource.GameSettingButton),
+		tgbotapi.NewKeyboardButtonRow(resource.RatingButton, resource.RulesButton),
+	)
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) matchWarnFn(_ context.Context, session *match.Session) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
@@ -427,19 +687,67 @@ func (m *manager) matchWarnFn(session *match.Session) error {
 	return nil
 }
 
-func (m *manager) matchDoneFn(session *match.Session) error {
+func (m *manager) matchDoneFn(ctx context.Context, session *match.Session) error {
+	if err := m.closeMatch(ctx, session); err != nil {
+		return fmt.Errorf("close match: %w", err)
+	}
+
+	if err := m.offerRematch(session); err != nil {
+		return fmt.Errorf("offer rematch: %w", err)
+	}
+
+	return nil
+}
+
+// closeMatch the results are stored and the players are released
+func (m *manager) closeMatch(ctx context.Context, session *match.Session) error {
+	announcements, err := m.releaseMatch(session)
+	if err != nil {
+		return err
+	}
+
+	m.announceAchievements(ctx, announcements)
+
+	// the event is written to the db, so it is emitted after the manager is unlocked
+	m.emitEvent(webhook.EventMatchFinished, webhook.MatchResult{
+		Code:   session.Config.Code,
+		Rounds: session.CurrRoundIdx + 1,
+		Scores: match.WebhookScores(session.Scores()),
+	})
+
+	return nil
+}
+
+// releaseMatch the announcements of the unlocked achievements are returned to be sent without the manager lock
+func (m *manager) releaseMatch(session *match.Session) ([]tgbotapi.MessageConfig, error) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
+	// the players are released even if the results could not be stored
+	defer func() {
+		for _, player := range session.Players {
+			delete(m.userMatchSessions, player.UserID)
+		}
+
+		delete(m.matchSessions, session.Code)
+	}()
+
 	if err := m.appendStat(session); err != nil {
-		return fmt.Errorf("append stat: %w", err)
+		return nil, fmt.Errorf("append stat: %w", err)
 	}
-	for _, player := range session.Players {
-		delete(m.userMatchSessions, player.UserID)
+
+	announcements, err := m.unlockAchievements(session)
+	if err != nil {
+		return nil, fmt.Errorf("unlock achievements: %w", err)
 	}
 
-	delete(m.matchSessions, session.Code)
+	return announcements, nil
+}
 
-	return nil
+// emitEvent the webhook delivery must not break the game, so errors are only logged
+func (m *manager) emitEvent(typ webhook.EventType, data interface{}) {
+	if err := m.webhook.Emit(typ, data); err != nil {
+		logging.DefaultLogger().Named("bloopsbot.manager.emitEvent").Errorf("emit %s: %v", typ, err)
+	}
 }
 
 func (m *manager) registerCommandHandler(cmd string, handler commandHandler) {
@@ -458,16 +766,51 @@ func (m *manager) commandHandler(cmd string) (commandHandler, bool) {
 func (m *manager) registerCommandCbHandler(userID int64, fn commandCbHandlerFunc) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
-	m.commandCbHandlers[userID] = fn
+	m.commandCbHandlers[userID] = commandCb{fn: fn, expiresAt: time.Now().Add(m.config.PromptTimeout)}
+}
+
+// commandCbHandler the expired prompt is dropped and the message goes further down the route
+func (m *manager) commandCbHandler(userID int64) (commandCbHandlerFunc, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	cb, ok := m.commandCbHandlers[userID]
+	if !ok {
+		return nil, false
+	}
+
+	if m.config.PromptTimeout > 0 && time.Now().After(cb.expiresAt) {
+		delete(m.commandCbHandlers, userID)
+		return nil, false
+	}
+
+	return cb.fn, true
+}
+
+func (m *manager) deleteCommandCbHandler(userID int64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.commandCbHandlers, userID)
 }
 
-func (m *manager) commandCbHandler(userID int64) (func(msg string) error, bool) {
+func (m *manager) registerQueryCbHandler(chatID int64, messageID int, fn queryCbHandlerFunc) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.queryCbHandlers[queryCbKey{chatID: chatID, messageID: messageID}] = fn
+}
+
+func (m *manager) queryCbHandler(chatID int64, messageID int) (queryCbHandlerFunc, bool) {
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
-	cb, ok := m.commandCbHandlers[userID]
+	cb, ok := m.queryCbHandlers[queryCbKey{chatID: chatID, messageID: messageID}]
 	return cb, ok
 }
 
+func (m *manager) deleteQueryCbHandler(chatID int64, messageID int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.queryCbHandlers, queryCbKey{chatID: chatID, messageID: messageID})
+}
+
 func (m *manager) resetUserSessions(userID int64) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
@@ -500,22 +843,52 @@ func (m *manager) matchSession(code int64) (*match.Session, bool) {
 	return session, ok
 }
 
-func (m *manager) shutdown() {
-	var ss int
-	m.cancelSess()
+// shutdown stops the sessions, each of them snapshots itself on the way out.
+// The sessions that did not make it before the deadline are snapshotted as is
+func (m *manager) shutdown(ctx context.Context) {
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.shutdown")
 	m.mtx.RLock()
-	bn, ms := len(m.userBuildingSessions), len(m.matchSessions)
+	done := make([]<-chan struct{}, 0, len(m.matchSessions)+len(m.userBuildingSessions))
+	for _, session := range m.matchSessions {
+		done = append(done, session.Done())
+	}
+
+	for _, session := range m.userBuildingSessions {
+		done = append(done, session.Done())
+	}
 	m.mtx.RUnlock()
-	ss = bn + ms
-	ticker := time.NewTicker(200 * time.Millisecond)
-	for ss > 0 {
+
+	logger.Infof("Stopping %d sessions", len(done))
+	m.cancelSess()
+
+	deadline := time.NewTimer(m.config.ShutdownTimeout)
+	defer deadline.Stop()
+
+	for _, ch := range done {
 		select {
-		case <-ticker.C:
-			m.mtx.RLock()
-			bn, ms := len(m.userBuildingSessions), len(m.matchSessions)
-			m.mtx.RUnlock()
-			ss = bn + ms
-		default:
+		case <-ch:
+		case <-deadline.C:
+			logger.Errorf("Sessions have not stopped in %v, saving them as is", m.config.ShutdownTimeout)
+			m.snapshotSessions(ctx)
+			return
+		}
+	}
+}
+
+func (m *manager) snapshotSessions(ctx context.Context) {
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.snapshotSessions")
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, session := range m.matchSessions {
+		if err := m.serializeGames(session); err != nil {
+			logger.Errorf("serialize match session %d: %v", session.Code, err)
+		}
+	}
+
+	for _, session := range m.userBuildingSessions {
+		if err := m.builderStateDB.Add(session.Serialize()); err != nil {
+			logger.Errorf("serialize building session %d: %v", session.AuthorID, err)
 		}
 	}
 }
@@ -556,6 +929,14 @@ func (m *manager) recvUser(upd tgbotapi.Update) (userModel.User, error) {
 		}
 	}
 
+	// the user writes to the bot again, so the bot is not blocked anymore
+	if u.BlockedBot {
+		u.BlockedBot = false
+		if err := m.userDB.Store(u); err != nil {
+			return u, fmt.Errorf("userdb store: %w", err)
+		}
+	}
+
 	stat, err := m.statDB.FetchRateStat(u.ID)
 	if err != nil {
 		if errors.Is(err, statDb.ErrNotFound) {
@@ -573,23 +954,36 @@ func (m *manager) recvUser(upd tgbotapi.Update) (userModel.User, error) {
 func NewMatchSessionFromSerialized(
 	ser matchstateModel.State,
 	tg *tgbotapi.BotAPI,
-	doneFn func(session *match.Session) error,
-	warnFn func(session *match.Session) error,
+	doneFn func(ctx context.Context, session *match.Session) error,
+	warnFn func(ctx context.Context, session *match.Session) error,
+	finishFn func(ctx context.Context, session *match.Session) error,
+	eventFn func(typ webhook.EventType, data interface{}),
 ) *match.Session {
 	c := match.Config{
-		AuthorID:   ser.AuthorID,
-		AuthorName: ser.AuthorName,
-		RoundsNum:  ser.RoundsNum,
-		RoundTime:  ser.RoundTime,
-		Categories: make([]string, len(ser.Categories)),
-		Letters:    make([]string, len(ser.Letters)),
-		Bloopses:   make([]resource.Bloops, len(ser.Bloopses)),
-		Vote:       ser.Vote,
-		Code:       ser.Code,
-		Timeout:    ser.Timeout,
-		Tg:         tg,
-		DoneFn:     doneFn,
-		WarnFn:     warnFn,
+		AuthorID:    ser.AuthorID,
+		AuthorName:  ser.AuthorName,
+		RoundsNum:   ser.RoundsNum,
+		RoundTime:   ser.RoundTime,
+		Categories:  make([]string, len(ser.Categories)),
+		Letters:     make([]string, len(ser.Letters)),
+		Bloopses:    make([]resource.Bloops, len(ser.Bloopses)),
+		Vote:        ser.Vote,
+		VoteMode:    match.VoteMode(ser.VoteMode),
+		VoteTime:    ser.VoteTime,
+		Scoring:     match.ScoringKind(ser.Scoring),
+		HotSeat:     ser.HotSeat,
+		LiveBoard:   ser.LiveBoard,
+		TieBreakers: match.ParseTieBreakers(ser.TieBreakers),
+		SuddenDeath: ser.SuddenDeath,
+		ViewToken:   ser.ViewToken,
+		Seed:        ser.Seed,
+		Code:        ser.Code,
+		Timeout:     ser.Timeout,
+		Tg:          tg,
+		DoneFn:      doneFn,
+		WarnFn:      warnFn,
+		FinishFn:    finishFn,
+		EventFn:     eventFn,
 	}
 
 	copy(c.Categories, ser.Categories)
@@ -605,28 +999,7 @@ func NewMatchSessionFromSerialized(
 }
 
 func (m *manager) serializeGames(session *match.Session) error {
-	s := matchstateModel.State{
-		Timeout:      session.Config.Timeout,
-		AuthorID:     session.Config.AuthorID,
-		AuthorName:   session.Config.AuthorName,
-		RoundsNum:    session.Config.RoundsNum,
-		RoundTime:    session.Config.RoundTime,
-		Vote:         session.Config.Vote,
-		Code:         session.Config.Code,
-		State:        session.State,
-		CurrRoundIdx: session.CurrRoundIdx,
-		CreatedAt:    session.CreatedAt,
-		Categories:   make([]string, len(session.Config.Categories)),
-		Letters:      make([]string, len(session.Config.Letters)),
-		Bloopses:     make([]resource.Bloops, len(session.Config.Bloopses)),
-		Players:      make([]*matchstateModel.Player, len(session.Players)),
-	}
-
-	copy(s.Categories, session.Config.Categories)
-	copy(s.Letters, session.Config.Letters)
-	copy(s.Bloopses, session.Config.Bloopses)
-	copy(s.Players, session.Players)
-
+	s := session.Serialize()
 	if err := m.stateDB.Add(s); err != nil {
 		return fmt.Errorf("state db add: %w", err)
 	}
@@ -642,7 +1015,7 @@ func (m *manager) restoreInterruptedGames() error {
 
 	m.mtx.Lock()
 	for _, state := range states {
-		session := NewMatchSessionFromSerialized(state, m.tg, m.matchDoneFn, m.matchWarnFn)
+		session := NewMatchSessionFromSerialized(state, m.tg, m.matchDoneFn, m.matchWarnFn, m.matchFinishFn, m.emitEvent)
 		session.Run(m.ctxSess)
 		m.matchSessions[session.Config.Code] = session
 		for _, player := range session.Players {
@@ -669,13 +1042,86 @@ func (m *manager) restoreInterruptedGames() error {
 	return nil
 }
 
+func NewBuilderSessionFromSerialized(
+	ser builderStateModel.State,
+	tg *tgbotapi.BotAPI,
+	doneFn func(session *builder.Session) error,
+	warnFn func(session *builder.Session) error,
+	timeout time.Duration,
+) (*builder.Session, error) {
+	s, err := builder.NewSession(tg, ser.ChatID, ser.AuthorID, ser.AuthorName, doneFn, warnFn, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("new builder session: %w", err)
+	}
+
+	s.RoundsNum = ser.RoundsNum
+	s.RoundTime = ser.RoundTime
+	s.Vote = ser.Vote
+	s.VoteMode = match.VoteMode(ser.VoteMode)
+	s.VoteTime = ser.VoteTime
+	s.Bloops = ser.Bloops
+	s.Scoring = match.ScoringKind(ser.Scoring)
+	s.HotSeat = ser.HotSeat
+	s.LiveBoard = ser.LiveBoard
+	s.TieBreakers = match.ParseTieBreakers(ser.TieBreakers)
+	s.SuddenDeath = ser.SuddenDeath
+	s.Categories = make([]resource.Category, len(ser.Categories))
+	s.Letters = make([]resource.Letter, len(ser.Letters))
+	s.PlayerNames = make([]string, len(ser.PlayerNames))
+
+	copy(s.Categories, ser.Categories)
+	copy(s.Letters, ser.Letters)
+	copy(s.PlayerNames, ser.PlayerNames)
+	s.SeekStage(ser.Stage)
+
+	return s, nil
+}
+
+func (m *manager) restoreInterruptedBuilders() error {
+	states, err := m.builderStateDB.FetchAll()
+	if err != nil && !errors.Is(err, builderStateDb.ErrEntryNotFound) {
+		return fmt.Errorf("builder state db fetch all: %w", err)
+	}
+
+	m.mtx.Lock()
+	for _, state := range states {
+		session, err := NewBuilderSessionFromSerialized(
+			state,
+			m.tg,
+			m.builderDoneFn,
+			m.builderWarnFn,
+			m.config.BuildingTimeout,
+		)
+		if err != nil {
+			m.mtx.Unlock()
+			return fmt.Errorf("new builder session from serialized: %w", err)
+		}
+
+		m.userBuildingSessions[session.AuthorID] = session
+		session.Run(m.ctxSess)
+	}
+	m.mtx.Unlock()
+
+	if len(states) > 0 {
+		if err := m.builderStateDB.Clean(); err != nil {
+			if !errors.Is(err, builderStateDb.ErrBucketNotFound) {
+				return fmt.Errorf("builder state db clean: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (m *manager) appendStat(session *match.Session) error {
 	favorites := session.Favorites()
+	scores := session.Scores()
 	stats := make([]statModel.Stat, 0)
 
 	for _, player := range session.Players {
 		stat := statModel.NewStat(player.UserID)
-		if player.Offline {
+		// the offline players of the old games share the author's id, only the guests have their own stat
+		if player.Offline && !player.IsGuest() {
 			continue
 		}
 
@@ -690,6 +1136,27 @@ func (m *manager) appendStat(session *match.Session) error {
 
 		stat.RoundsNum = session.Config.RoundsNum
 		stat.PlayersNum = len(session.Players)
+		stat.MatchCode = session.Config.Code
+		for _, score := range scores {
+			if score.Player.Offline && !score.Player.IsGuest() {
+				continue
+			}
+
+			if score.Player.UserID == player.UserID {
+				stat.Place = score.Place
+				continue
+			}
+
+			stat.CoPlayers = append(stat.CoPlayers, statModel.CoPlayer{
+				UserID: score.Player.UserID,
+				Name:   score.Player.User.FirstName,
+				Points: score.Points,
+				Place:  score.Place,
+			})
+		}
+
+		stat.LetterPerformance = statModel.Performances{}
+		stat.CategoryPerformance = statModel.Performances{}
 
 		var (
 			bestDuration, worstDuration time.Duration = 2 << 31, 0
@@ -700,6 +1167,14 @@ func (m *manager) appendStat(session *match.Session) error {
 
 		for _, rate := range player.Rates {
 			if !rate.Bloops {
+				// the bloops rounds have their own task, the letter does not make them harder
+				if rate.Letter != "" {
+					stat.LetterPerformance.Add(rate.Letter, rate.Completed, rate.Duration)
+					for _, category := range rate.Categories {
+						stat.CategoryPerformance.Add(category, rate.Completed, rate.Duration)
+					}
+				}
+
 				durationNum += 1
 				if rate.Duration < bestDuration {
 					bestDuration = rate.Duration
@@ -710,6 +1185,13 @@ func (m *manager) appendStat(session *match.Session) error {
 				sumDuration += rate.Duration
 			} else {
 				stat.Bloops = append(stat.Bloops, rate.BloopsName)
+				if rate.Completed {
+					stat.CompletedBloops++
+				}
+			}
+
+			if rate.IsCorrected() {
+				stat.CorrectedRounds++
 			}
 
 			pointsNum += 1
@@ -741,10 +1223,18 @@ func (m *manager) appendStat(session *match.Session) error {
 		stats = append(stats, stat)
 	}
 
+	difficulty := statModel.Difficulty{Letters: statModel.Performances{}, Categories: statModel.Performances{}}
 	for _, stat := range stats {
 		if err := m.statDB.Add(stat); err != nil {
 			return fmt.Errorf("stat db add: %w", err)
 		}
+
+		difficulty.Letters.Merge(stat.LetterPerformance)
+		difficulty.Categories.Merge(stat.CategoryPerformance)
+	}
+
+	if err := m.statDB.AddDifficulty(difficulty); err != nil {
+		return fmt.Errorf("stat db add difficulty: %w", err)
 	}
 
 	return nil
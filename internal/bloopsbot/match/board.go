@@ -0,0 +1,183 @@
+package match
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/logging"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// the timer changes every second, the board is edited less often to stay within the telegram limits
+const boardEditInterval = 3 * time.Second
+
+// transientMessage the message that is useless after the turn, deleted in the live board mode
+type transientMessage struct {
+	chatID    int64
+	messageID int
+}
+
+func (r *Session) keepTransient(chatID int64, messageID int) {
+	if !r.Config.LiveBoard {
+		return
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.transient = append(r.transient, transientMessage{chatID: chatID, messageID: messageID})
+}
+
+// cleanTransient deletes the letter roulette, the countdown, the timer and the vote buttons of the finished turn
+func (r *Session) cleanTransient(ctx context.Context) {
+	logger := logging.FromContext(ctx).Named("match.cleanTransient")
+
+	r.mtx.Lock()
+	messages := r.transient
+	r.transient = nil
+	r.mtx.Unlock()
+
+	for _, m := range messages {
+		if _, err := r.tg.DeleteMessage(tgbotapi.NewDeleteMessage(m.chatID, m.messageID)); err != nil {
+			logger.Errorf("delete message: %v", err)
+		}
+	}
+}
+
+// boardLoop keeps the pinned boards of the players up to date until the match is over
+func (r *Session) boardLoop(ctx context.Context) {
+	updates, unsubscribe := r.Subscribe()
+	defer unsubscribe()
+
+	// chat id -> message id of the pinned board
+	boards := map[int64]int{}
+	defer r.unpinBoards(ctx, boards)
+
+	var sent string
+	for range updates {
+		view := r.View()
+		if view.State == stateTitle(StateKindWaiting) {
+			continue
+		}
+
+		text := renderBoard(view)
+		if text == sent {
+			continue
+		}
+
+		r.updateBoards(ctx, boards, text)
+		sent = text
+		// the changes made during the pause are shown by the next edit
+		r.clock.Sleep(boardEditInterval)
+	}
+}
+
+// updateBoards edits the boards, the players who have joined later get a new pinned board
+func (r *Session) updateBoards(ctx context.Context, boards map[int64]int, text string) {
+	logger := logging.FromContext(ctx).Named("match.updateBoards")
+	for chatID, messageID := range boards {
+		if _, err := r.tg.Send(tgbotapi.NewEditMessageText(chatID, messageID, text)); err != nil {
+			logger.Errorf("edit board in %d: %v", chatID, err)
+		}
+	}
+
+	for _, chatID := range r.chatsWithoutBoard(boards) {
+		output, err := r.tg.Send(tgbotapi.NewMessage(chatID, text))
+		if err != nil {
+			logger.Errorf("send board to %d: %v", chatID, err)
+			continue
+		}
+
+		boards[chatID] = output.MessageID
+		pin := tgbotapi.PinChatMessageConfig{ChatID: chatID, MessageID: output.MessageID, DisableNotification: true}
+		if _, err := r.tg.PinChatMessage(pin); err != nil {
+			logger.Errorf("pin board in %d: %v", chatID, err)
+		}
+	}
+}
+
+// chatsWithoutBoard the guests and the hot seat players share the chat of the owner
+func (r *Session) chatsWithoutBoard(boards map[int64]int) []int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	seen := map[int64]struct{}{}
+	var chats []int64
+	for _, player := range r.Players {
+		if _, ok := boards[player.ChatID]; ok || !player.IsPlaying() || player.Offline {
+			continue
+		}
+
+		if _, ok := seen[player.ChatID]; ok {
+			continue
+		}
+
+		seen[player.ChatID] = struct{}{}
+		chats = append(chats, player.ChatID)
+	}
+
+	return chats
+}
+
+// unpinBoards the final board stays in the chat as a usual message
+func (r *Session) unpinBoards(ctx context.Context, boards map[int64]int) {
+	logger := logging.FromContext(ctx).Named("match.unpinBoards")
+	for chatID := range boards {
+		if _, err := r.tg.UnpinChatMessage(tgbotapi.UnpinChatMessageConfig{ChatID: chatID}); err != nil {
+			logger.Errorf("unpin board in %d: %v", chatID, err)
+		}
+	}
+}
+
+// renderBoard the text of the board, plain text because the names are not escaped
+func renderBoard(view View) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	if view.SuddenDeath {
+		_, _ = fmt.Fprintf(buf, "%s Игра %d, дополнительный раунд\n\n", emoji.Pushpin.String(), view.Code)
+	} else {
+		_, _ = fmt.Fprintf(buf, "%s Игра %d, раунд %d из %d\n\n", emoji.Pushpin.String(), view.Code, view.Round, view.RoundsNum)
+	}
+
+	switch view.State {
+	case stateTitle(StateKindFinished):
+		_, _ = fmt.Fprintf(buf, "%s Игра завершена\n", emoji.ChequeredFlag.String())
+	case stateTitle(StateKindProcessing):
+		_, _ = fmt.Fprintf(buf, resource.TextRoundFavoriteMsg+"\n", view.Round)
+	default:
+		if view.Player != "" {
+			_, _ = fmt.Fprintf(buf, "%s Ходит %s\n", emoji.GameDie.String(), view.Player)
+		}
+
+		if view.Letter != "" {
+			_, _ = fmt.Fprintf(buf, "%s Буква %s: %s\n", emoji.Pen.String(), view.Letter, strings.Join(view.Categories, ", "))
+		}
+
+		if view.Seconds > 0 {
+			_, _ = fmt.Fprintf(buf, "%s %d сек\n", emoji.Stopwatch.String(), view.Seconds)
+		}
+
+		if view.Vote != nil {
+			_, _ = fmt.Fprintf(buf, "%s Голосование", emoji.Loudspeaker.String())
+			if !view.Vote.Hidden {
+				_, _ = fmt.Fprintf(buf, ": %d %s / %d %s", view.Vote.Up, resource.TextThumbUp, view.Vote.Down, resource.TextThumbDown)
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	_, _ = fmt.Fprintf(buf, "\n%s Очки\n", emoji.Trophy.String())
+	for _, score := range view.Scores {
+		_, _ = fmt.Fprintf(buf, "%d. %s - %d\n", score.Place, score.Name, score.Points)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,63 @@
+package match
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderBoard(t *testing.T) {
+	t.Parallel()
+
+	scores := []ViewScore{{Name: "Маша", Points: 12, Place: 1}, {Name: "Петя", Points: 5, Place: 2}}
+	testCases := []struct {
+		name        string
+		view        View
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "turn",
+			view: View{
+				Code: 1234, State: "playing", Round: 2, RoundsNum: 3, Player: "Петя", Letter: "А",
+				Categories: []string{"Города", "Реки"}, Seconds: 42, Scores: scores,
+			},
+			contains: []string{"Игра 1234, раунд 2 из 3", "Ходит Петя", "Буква А: Города, Реки", "42 сек", "1. Маша - 12", "2. Петя - 5"},
+		},
+		{
+			name:        "hidden_vote",
+			view:        View{State: "playing", Round: 1, RoundsNum: 1, Player: "Петя", Vote: &ViewVote{Up: 1, Hidden: true}},
+			contains:    []string{"Голосование"},
+			notContains: []string{"1 👍"},
+		},
+		{
+			name:     "sudden_death",
+			view:     View{Code: 1234, State: "playing", Round: 4, RoundsNum: 3, SuddenDeath: true},
+			contains: []string{"Игра 1234, дополнительный раунд"},
+		},
+		{
+			name:        "finished",
+			view:        View{State: "finished", Round: 3, RoundsNum: 3, Player: "Петя", Scores: scores},
+			contains:    []string{"Игра завершена", "1. Маша - 12"},
+			notContains: []string{"Ходит"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			text := renderBoard(tc.view)
+			for _, s := range tc.contains {
+				if !strings.Contains(text, s) {
+					t.Errorf("expected %q in board:\n%s", s, text)
+				}
+			}
+
+			for _, s := range tc.notContains {
+				if strings.Contains(text, s) {
+					t.Errorf("unexpected %q in board:\n%s", s, text)
+				}
+			}
+		})
+	}
+}
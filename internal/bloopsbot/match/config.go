@@ -1,9 +1,12 @@
 package match
 
 import (
+	"context"
 	"time"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/clock"
+	"github.com/bloops-games/bloops/internal/webhook"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
@@ -16,17 +19,63 @@ type Config struct {
 	Letters    []string          `json:"letters"`
 	Bloopses   []resource.Bloops `json:"bloopses"`
 	Vote       bool              `json:"vote"`
+	VoteMode   VoteMode          `json:"voteMode"`
+	VoteTime   int               `json:"voteTime"`
 	Code       int64             `json:"code"`
+	Scoring    ScoringKind       `json:"scoring"`
+	// all players share the author's phone, the turns are passed from hand to hand
+	HotSeat bool `json:"hotSeat"`
+	// the players with the same points are compared by the tie-breakers in this order
+	TieBreakers []TieBreaker `json:"tieBreakers"`
+	// the leaders who are still tied after the last round play the extra rounds
+	SuddenDeath bool `json:"suddenDeath"`
+	// every player has one pinned message with the state of the match instead of the broadcasts of each turn
+	LiveBoard bool `json:"liveBoard"`
+	// secret part of the presenter screen link
+	ViewToken string `json:"viewToken"`
+	// seed of the random source, the match with the same seed deals the same letters and turns
+	Seed int64 `json:"seed"`
 
 	State        uint8 `json:"state"`
 	CurrRoundIdx int   `json:"currRoundIdx"`
 
-	Tg      *tgbotapi.BotAPI             `json:"-"`
-	DoneFn  func(session *Session) error `json:"-"`
-	WarnFn  func(session *Session) error `json:"-"`
-	Timeout time.Duration                `json:"-"`
+	Tg     *tgbotapi.BotAPI                                  `json:"-"`
+	DoneFn func(ctx context.Context, session *Session) error `json:"-"`
+	WarnFn func(ctx context.Context, session *Session) error `json:"-"`
+	// the match is finished and the results are final, the players are still in the lobby
+	FinishFn func(ctx context.Context, session *Session) error `json:"-"`
+	// outbound webhook events of the match lifecycle
+	EventFn func(typ webhook.EventType, data interface{}) `json:"-"`
+	Timeout time.Duration                                 `json:"-"`
+	// the real clock is used if nil
+	Clock clock.Clock `json:"-"`
 }
 
 func (c Config) IsBloops() bool {
 	return len(c.Bloopses) > 0
 }
+
+func (c Config) IsTyped() bool {
+	return c.Scoring == ScoringKindWords
+}
+
+// tieTitle the tie-breakers in the order they are applied
+func (c Config) tieTitle() string {
+	return TieTitle(c.TieBreakers, c.SuddenDeath)
+}
+
+func (c Config) voteMode() VoteMode {
+	if c.VoteMode == 0 {
+		return VoteModeAll
+	}
+
+	return c.VoteMode
+}
+
+func (c Config) voteTimeout() time.Duration {
+	if c.VoteTime <= 0 {
+		return defaultInactiveVoteTime * time.Second
+	}
+
+	return time.Duration(c.VoteTime) * time.Second
+}
@@ -0,0 +1,248 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	"github.com/bloops-games/bloops/internal/logging"
+)
+
+// the corrected points and the reason of the host are limited to keep the messages readable
+const (
+	MaxCorrectionPoints = 999
+	maxReasonLen        = 200
+)
+
+var (
+	ErrNotHost         = fmt.Errorf("only the host can correct the points")
+	ErrMatchNotPlaying = fmt.Errorf("the match is not playing")
+	ErrRateNotFound    = fmt.Errorf("rate not found")
+	ErrReasonRequired  = fmt.Errorf("reason required")
+	ErrInvalidPoints   = fmt.Errorf("invalid points")
+	ErrVoteDisabled    = fmt.Errorf("vote disabled")
+	ErrVoteInProgress  = fmt.Errorf("vote in progress")
+	ErrNoVoters        = fmt.Errorf("no voters")
+	ErrNothingToRevote = fmt.Errorf("the turn scored no points")
+)
+
+// the reason is typed by the host and sent with the markdown
+var markdownReplacer = strings.NewReplacer("*", "", "_", "", "`", "", "[", "")
+
+// CorrectionRound the played round of the player for the host to choose from
+type CorrectionRound struct {
+	Idx    int
+	Letter string
+	Points int
+}
+
+// Correct the host sets the points of the played round, the completion of the turn stays the same
+func (r *Session) Correct(hostID, userID int64, roundIdx, points int, reason string) error {
+	if hostID != r.Config.AuthorID {
+		return ErrNotHost
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+
+	if points > MaxCorrectionPoints || points < -MaxCorrectionPoints {
+		return ErrInvalidPoints
+	}
+
+	player, rate, err := r.findRate(userID, roundIdx)
+	if err != nil {
+		return err
+	}
+
+	r.mtx.RLock()
+	completed := rate.Completed
+	r.mtx.RUnlock()
+
+	correction, err := r.correct(rate, points, completed, reason, false)
+	if err != nil {
+		return err
+	}
+
+	r.announceCorrection(player, roundIdx, correction)
+
+	return nil
+}
+
+// Revote the host re-opens the vote on the answer of the played round, the vote runs in the background
+func (r *Session) Revote(hostID, userID int64, roundIdx int) error {
+	if hostID != r.Config.AuthorID {
+		return ErrNotHost
+	}
+
+	if !r.Config.Vote {
+		return ErrVoteDisabled
+	}
+
+	player, rate, err := r.findRate(userID, roundIdx)
+	if err != nil {
+		return err
+	}
+
+	r.mtx.RLock()
+	scoredPoints, scoredCompleted, letter := rate.ScoredPoints, rate.ScoredCompleted, rate.Letter
+	ctx := r.ctx
+	r.mtx.RUnlock()
+
+	if scoredPoints <= 0 {
+		return ErrNothingToRevote
+	}
+
+	if len(r.voters(player)) == 0 {
+		return ErrNoVoters
+	}
+
+	select {
+	case r.voteSema <- struct{}{}:
+	default:
+		return ErrVoteInProgress
+	}
+
+	go func() {
+		defer func() { <-r.voteSema }()
+		logger := logging.FromContext(ctx).Named("match.Revote")
+
+		subject := fmt.Sprintf(resource.TextRevoteSubject, player.FormatFirstName(), roundIdx+1, letter)
+		accepted, ok, err := r.runVote(ctx, player, subject, map[int64]int{})
+		if err != nil {
+			logger.Errorf("run vote: %v", err)
+			return
+		}
+
+		if !ok {
+			return
+		}
+
+		points, completed := 0, false
+		if accepted {
+			points, completed = scoredPoints, scoredCompleted
+		}
+
+		correction, err := r.correct(rate, points, completed, resource.TextRevoteReason, true)
+		if err != nil {
+			logger.Errorf("correct: %v", err)
+			return
+		}
+
+		r.announceCorrection(player, roundIdx, correction)
+	}()
+
+	return nil
+}
+
+// CorrectionPlayers the playing players whose rounds can be corrected
+func (r *Session) CorrectionPlayers() []model.Player {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	var players []model.Player
+	for _, player := range r.Players {
+		if player.IsPlaying() {
+			players = append(players, *player)
+		}
+	}
+
+	return players
+}
+
+// CorrectionRounds the played rounds of the player, the round of the current turn is not played yet
+func (r *Session) CorrectionRounds(userID int64) []CorrectionRound {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	var rounds []CorrectionRound
+	for _, player := range r.Players {
+		if player.UserID != userID {
+			continue
+		}
+
+		for idx, rate := range player.Rates {
+			rounds = append(rounds, CorrectionRound{Idx: idx, Letter: rate.Letter, Points: rate.Points})
+		}
+	}
+
+	return rounds
+}
+
+// correct records the original and the adjusted points in the rate, the stats being written are not changed
+func (r *Session) correct(rate *model.Rate, points int, completed bool, reason string, revote bool) (model.Correction, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.closing {
+		return model.Correction{}, ErrMatchNotPlaying
+	}
+
+	if runes := []rune(reason); len(runes) > maxReasonLen {
+		reason = string(runes[:maxReasonLen])
+	}
+
+	correction := model.Correction{
+		OriginalPoints: rate.Points,
+		AdjustedPoints: points,
+		Reason:         reason,
+		Revote:         revote,
+		CreatedAt:      r.clock.Now(),
+	}
+
+	rate.Points = points
+	rate.Completed = completed
+	rate.Corrections = append(rate.Corrections, correction)
+
+	return correction, nil
+}
+
+// announceCorrection the results of the finished match are sent again, the favorites could change
+func (r *Session) announceCorrection(player *model.Player, roundIdx int, correction model.Correction) {
+	r.publishView()
+	r.syncBroadcast(renderCorrectionMsg(player, roundIdx, correction))
+	if r.getState() == StateKindFinished {
+		r.syncBroadcast(r.renderGameFavorites(r.Favorites()))
+		r.syncBroadcast(r.renderScores())
+	}
+}
+
+// findRate the rate of the played round, the points can be corrected until the stats of the match are written
+func (r *Session) findRate(userID int64, roundIdx int) (*model.Player, *model.Rate, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	switch {
+	case r.State == StateKindPlaying, r.State == StateKindProcessing:
+	case r.State == StateKindFinished && !r.closing:
+	default:
+		return nil, nil, ErrMatchNotPlaying
+	}
+
+	for _, player := range r.Players {
+		if player.UserID != userID {
+			continue
+		}
+
+		if roundIdx < 0 || roundIdx >= len(player.Rates) {
+			return nil, nil, ErrRateNotFound
+		}
+
+		return player, player.Rates[roundIdx], nil
+	}
+
+	return nil, nil, ErrPlayerNotFound
+}
+
+func renderCorrectionMsg(player *model.Player, roundIdx int, correction model.Correction) string {
+	return fmt.Sprintf(
+		resource.TextCorrectionMsg,
+		player.FormatFirstName(),
+		roundIdx+1,
+		correction.OriginalPoints,
+		correction.AdjustedPoints,
+		markdownReplacer.Replace(correction.Reason),
+	)
+}
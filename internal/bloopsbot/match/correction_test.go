@@ -0,0 +1,76 @@
+package match
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+)
+
+func TestSessionCorrect(t *testing.T) {
+	t.Parallel()
+
+	s := NewSession(Config{Code: 1234, AuthorID: 1, RoundsNum: 2})
+	s.State = StateKindPlaying
+	for i, points := range []int{10, 8} {
+		// the offline players get no broadcast
+		player := model.NewPlayer(int64(i+1), userModel.User{ID: int64(i + 1)}, true)
+		player.Rates = append(player.Rates, &model.Rate{Points: points, Completed: true, ScoredPoints: points, ScoredCompleted: true})
+		s.Players = append(s.Players, player)
+	}
+
+	testCases := []struct {
+		name     string
+		hostID   int64
+		userID   int64
+		roundIdx int
+		points   int
+		reason   string
+		err      error
+	}{
+		{name: "not_host", hostID: 2, userID: 1, points: 0, reason: "нет такой реки", err: ErrNotHost},
+		{name: "no_reason", hostID: 1, userID: 1, points: 0, reason: " ", err: ErrReasonRequired},
+		{name: "invalid_points", hostID: 1, userID: 1, points: MaxCorrectionPoints + 1, reason: "бонус", err: ErrInvalidPoints},
+		{name: "round_not_played", hostID: 1, userID: 1, roundIdx: 1, points: 0, reason: "нет такой реки", err: ErrRateNotFound},
+		{name: "player_not_found", hostID: 1, userID: 3, points: 0, reason: "нет такой реки", err: ErrPlayerNotFound},
+	}
+
+	for _, tc := range testCases {
+		if err := s.Correct(tc.hostID, tc.userID, tc.roundIdx, tc.points, tc.reason); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.err, err)
+		}
+	}
+
+	if err := s.Correct(1, 1, 0, 0, "нет такой реки"); err != nil {
+		t.Fatalf("correct: %v", err)
+	}
+
+	rate := s.Players[0].Rates[0]
+	if !rate.IsCorrected() || rate.Points != 0 || !rate.Completed {
+		t.Fatalf("expected the corrected points of the completed turn, got %+v", rate)
+	}
+
+	if c := rate.Corrections[0]; c.OriginalPoints != 10 || c.AdjustedPoints != 0 || c.Reason != "нет такой реки" || c.Revote {
+		t.Errorf("unexpected correction %+v", c)
+	}
+
+	if favorites := s.Favorites(); len(favorites) != 1 || favorites[0].Player.UserID != 2 {
+		t.Errorf("expected the favorite after the correction, got %+v", favorites)
+	}
+
+	// the last turn of the finished match can be disputed until the stats are written
+	s.State = StateKindFinished
+	if err := s.Correct(1, 2, 0, 12, "успел до конца таймера"); err != nil {
+		t.Fatalf("correct the finished match: %v", err)
+	}
+
+	if favorites := s.Favorites(); len(favorites) != 1 || favorites[0].Player.UserID != 2 || favorites[0].Points != 12 {
+		t.Errorf("expected the corrected favorite, got %+v", favorites)
+	}
+
+	s.closing = true
+	if err := s.Correct(1, 2, 0, 0, "нет такой реки"); !errors.Is(err, ErrMatchNotPlaying) {
+		t.Errorf("expected %v, got %v", ErrMatchNotPlaying, err)
+	}
+}
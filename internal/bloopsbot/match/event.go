@@ -0,0 +1,54 @@
+package match
+
+import (
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	"github.com/bloops-games/bloops/internal/webhook"
+)
+
+func (r *Session) emit(typ webhook.EventType, data interface{}) {
+	if r.Config.EventFn != nil {
+		r.Config.EventFn(typ, data)
+	}
+}
+
+func (r *Session) webhookPlayer(player *model.Player) webhook.Player {
+	return webhook.Player{
+		Code:      r.Config.Code,
+		UserID:    player.UserID,
+		FirstName: player.FormatFirstName(),
+		Offline:   player.Offline,
+	}
+}
+
+func (r *Session) webhookMatch() webhook.Match {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	match := webhook.Match{Code: r.Config.Code, Players: make([]webhook.Player, 0, len(r.Players))}
+	for _, player := range r.Players {
+		if player.IsPlaying() {
+			match.Players = append(match.Players, r.webhookPlayer(player))
+		}
+	}
+
+	return match
+}
+
+func (r *Session) webhookRound() webhook.Round {
+	return webhook.Round{Code: r.Config.Code, Round: r.CurrRoundIdx + 1, Scores: WebhookScores(r.Scores())}
+}
+
+// WebhookScores the scores in the webhook payload format
+func WebhookScores(scores []PlayerScore) []webhook.Score {
+	list := make([]webhook.Score, len(scores))
+	for i, score := range scores {
+		list[i] = webhook.Score{
+			UserID:    score.Player.UserID,
+			FirstName: score.Player.FormatFirstName(),
+			Points:    score.Points,
+			Place:     score.Place,
+		}
+	}
+
+	return list
+}
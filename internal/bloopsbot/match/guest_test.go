@@ -0,0 +1,75 @@
+package match
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+)
+
+func TestSessionRemoveGuest(t *testing.T) {
+	t.Parallel()
+
+	owner := userModel.User{ID: 1, FirstName: "Маша"}
+	s := NewSession(Config{Code: 1234, AuthorID: owner.ID})
+	s.Players = append(
+		s.Players,
+		model.NewPlayer(1, owner, false),
+		model.NewPlayer(2, userModel.User{ID: 2, FirstName: "Петя"}, false),
+		model.NewGuestPlayer(1, owner, -1, "Бабушка"),
+	)
+
+	if guests := s.Guests(owner.ID); len(guests) != 1 || guests[0].UserID != -1 || guests[0].ControllerID() != owner.ID {
+		t.Fatalf("expected one guest of the owner, got %+v", guests)
+	}
+
+	if guest, ok := s.FindGuest(owner.ID, "бабушка"); !ok || guest.UserID != -1 {
+		t.Errorf("expected the guest of the owner, got %+v", guest)
+	}
+
+	if _, ok := s.FindGuest(2, "Бабушка"); ok {
+		t.Errorf("expected the guest not to be found for another player")
+	}
+
+	if err := s.RemoveGuest(2, -1); !errors.Is(err, ErrNotGuestOwner) {
+		t.Errorf("expected %v, got %v", ErrNotGuestOwner, err)
+	}
+
+	if err := s.RemoveGuest(owner.ID, 2); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected %v, got %v", ErrPlayerNotFound, err)
+	}
+
+	if err := s.RemoveGuest(owner.ID, -2); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected %v, got %v", ErrPlayerNotFound, err)
+	}
+
+	// the guests leave together with their owner
+	s.removePlayer(owner.ID)
+	if guests := s.Guests(owner.ID); len(guests) != 0 {
+		t.Errorf("expected no guests after the owner left, got %+v", guests)
+	}
+}
+
+func TestSessionAddPlayerAgain(t *testing.T) {
+	t.Parallel()
+
+	s := NewSession(Config{Code: 1234, AuthorID: 1})
+	owner := userModel.User{ID: 1, FirstName: "Маша"}
+	left := model.NewPlayer(1, owner, false)
+	left.State = model.PlayerStateKindLeaving
+	s.Players = append(s.Players, left, model.NewGuestPlayer(1, owner, -1, "Бабушка"))
+
+	player, ok := s.addPlayer(model.NewPlayer(1, owner, false))
+	if !ok || player != left || !player.IsPlaying() {
+		t.Fatalf("expected the player who left to play again, got %+v", player)
+	}
+
+	if _, ok := s.addPlayer(model.NewPlayer(1, owner, false)); ok {
+		t.Errorf("expected the playing player not to be added twice")
+	}
+
+	if n := len(s.Players); n != 2 {
+		t.Errorf("expected 2 players, got %d", n)
+	}
+}
@@ -2,20 +2,18 @@ package match
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
-	"github.com/bloops-games/bloops/internal/bloopsbot/util"
 	"github.com/bloops-games/bloops/internal/database/matchstate/model"
 	"github.com/bloops-games/bloops/internal/logging"
 	"github.com/bloops-games/bloops/internal/strpool"
 	"github.com/enescakir/emoji"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"github.com/valyala/fastrand"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -33,6 +31,7 @@ func (r *Session) sendStartMsg(player *model.Player) error {
 		return fmt.Errorf("send msg: %w", err)
 	}
 
+	r.keepTransient(player.ChatID, output.MessageID)
 	r.registerCbHandler(output.MessageID, func(query *tgbotapi.CallbackQuery) error {
 		if query.Data == resource.TextStartBtnData {
 			if _, err := r.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.TextStartBtnDataAnswer)); err != nil {
@@ -57,13 +56,13 @@ func (r *Session) checkBloopsSendMsg(player *model.Player) (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("send msg: %w", err)
 	}
-	util.Sleep(1 * time.Second)
+	r.clock.Sleep(1 * time.Second)
 	for i := 3; i > 0; i-- {
 		msg := tgbotapi.NewEditMessageText(player.ChatID, output.MessageID, emoji.GameDie.String()+"..."+strconv.Itoa(i))
 		if _, err := r.tg.Send(msg); err != nil {
 			return output.MessageID, fmt.Errorf("send msg: %w", err)
 		}
-		util.Sleep(1 * time.Second)
+		r.clock.Sleep(1 * time.Second)
 	}
 
 	return output.MessageID, nil
@@ -76,7 +75,7 @@ func (r *Session) sendDroppedBloopsesMsg(player *model.Player, bloops *resource.
 			return fmt.Errorf("send msg: %w", err)
 		}
 	}
-	util.Sleep(1 * time.Second)
+	r.clock.Sleep(1 * time.Second)
 	{
 		msg := tgbotapi.NewMessage(player.ChatID, r.renderDropBloopsMsg(bloops))
 		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
@@ -111,14 +110,15 @@ func (r *Session) sendDroppedBloopsesMsg(player *model.Player, bloops *resource.
 }
 
 // select the letter that the player needs to call the words
-func (r *Session) sendLetterMsg(player *model.Player) error {
+func (r *Session) sendLetterMsg(player *model.Player) (string, error) {
 	buf := strpool.Get()
 
 	output, err := r.tg.Send(tgbotapi.NewMessage(player.ChatID, resource.TextStartLetterMsg))
 	if err != nil {
-		return fmt.Errorf("send msg: %w", err)
+		return "", fmt.Errorf("send msg: %w", err)
 	}
 
+	r.keepTransient(player.ChatID, output.MessageID)
 	sndCh := make(chan string, 1)
 
 	g := errgroup.Group{}
@@ -136,7 +136,7 @@ func (r *Session) sendLetterMsg(player *model.Player) error {
 	for i := 0; i < generateLetterTimes; i++ {
 		for buf.String() == sentMsg {
 			buf.Reset()
-			idx := fastrand.Uint32n(uint32(len(r.Config.Letters)))
+			idx := r.rnd.Intn(len(r.Config.Letters))
 			buf.WriteString(resource.TextStartLetterMsg)
 			buf.WriteString(r.Config.Letters[idx])
 			sentLetter = r.Config.Letters[idx]
@@ -144,21 +144,25 @@ func (r *Session) sendLetterMsg(player *model.Player) error {
 
 		sndCh <- buf.String()
 		sentMsg = buf.String()
-		util.Sleep(300 * time.Millisecond)
+		r.clock.Sleep(300 * time.Millisecond)
 	}
 
 	buf.Reset()
 	strpool.Put(buf)
 
-	r.syncBroadcast(r.renderStartHelpMsg(player, sentLetter), player.UserID)
+	r.setTurnLetter(sentLetter)
+	// the live board shows the letter and the categories
+	if !r.Config.LiveBoard {
+		r.syncBroadcast(r.renderStartHelpMsg(player, sentLetter), player.UserID)
+	}
 
 	close(sndCh)
 
 	if err := g.Wait(); err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return sentLetter, nil
 }
 
 // send ready -> set -> go steps
@@ -181,7 +185,8 @@ func (r *Session) sendReadyMsg(player *model.Player) error {
 			return fmt.Errorf("send msg: %w", err)
 		}
 		messageID = output.MessageID
-		util.Sleep(1 * time.Second)
+		r.keepTransient(player.ChatID, messageID)
+		r.clock.Sleep(1 * time.Second)
 	}
 
 	buf.Reset()
@@ -194,7 +199,7 @@ func (r *Session) sendReadyMsg(player *model.Player) error {
 			return fmt.Errorf("send msg: %w", err)
 		}
 
-		util.Sleep(1 * time.Second)
+		r.clock.Sleep(1 * time.Second)
 	}
 
 	buf.Reset()
@@ -208,7 +213,7 @@ func (r *Session) sendReadyMsg(player *model.Player) error {
 			return fmt.Errorf("send msg: %w", err)
 		}
 
-		util.Sleep(1 * time.Second)
+		r.clock.Sleep(1 * time.Second)
 	}
 
 	buf.Reset()
@@ -286,18 +291,24 @@ func (r *Session) sendWorkingTimerMsg(player *model.Player, messageID, secs int)
 	return nil
 }
 
-func (r *Session) sendVotesMsg(voteMessages map[int64]int) error {
-	markup := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			thumbUpButton(r.activeVote.thumbUp),
-			thumbDownButton(r.activeVote.thumbDown),
-		),
-	)
+// sendVotesMsg the subject explains the re-opened vote, empty for the vote of the current turn
+func (r *Session) sendVotesMsg(voteMessages map[int64]int, subject string) error {
+	markup := r.renderVoteButtons()
+	text := resource.TextVoteMsg
+	if r.activeVote.mode == VoteModeHost {
+		text = resource.TextVoteHostMsg
+	}
 
+	if subject != "" {
+		text = subject + "\n\n" + text
+	}
+
+	// the buttons of the message belong to this vote, a late tap does not reach the next one
+	v := r.activeVote
 	// creating a voting system and defining callbacks for voting
 	for _, player := range r.Players {
-		if player.IsPlaying() && !player.Offline {
-			msg := tgbotapi.NewMessage(player.ChatID, resource.TextVoteMsg)
+		if _, ok := r.activeVote.voters[player.UserID]; ok && player.IsPlaying() && !player.Offline {
+			msg := tgbotapi.NewMessage(player.ChatID, text)
 			msg.ReplyMarkup = markup
 			// sending the thumbs up and thumbs down buttons
 			output, err := r.tg.Send(msg)
@@ -307,15 +318,24 @@ func (r *Session) sendVotesMsg(voteMessages map[int64]int) error {
 			// registering callbacks for voting
 			voteMessages[player.ChatID] = output.MessageID
 			r.registerCbHandler(output.MessageID, func(query *tgbotapi.CallbackQuery) error {
+				var err error
 				switch query.Data {
 				case resource.TextThumbUp:
-					r.thumbUp()
+					err = r.castVote(v, int64(query.From.ID), true)
 				case resource.TextThumbDown:
-					r.thumbDown()
+					err = r.castVote(v, int64(query.From.ID), false)
 				default:
 				}
 
-				if _, err := r.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, query.Data)); err != nil {
+				answer := query.Data
+				switch {
+				case errors.Is(err, ErrVoteTwice):
+					answer = resource.TextVoteTwiceAnswer
+				case errors.Is(err, ErrVoteNotAllowed), errors.Is(err, ErrVoteClosed):
+					answer = resource.TextVoteNotAllowedAnswer
+				}
+
+				if _, err := r.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, answer)); err != nil {
 					return fmt.Errorf("send answer msg: %w", err)
 				}
 
@@ -329,28 +349,23 @@ func (r *Session) sendVotesMsg(voteMessages map[int64]int) error {
 
 func (r *Session) sendChangingVotesMsg(voteMessages map[int64]int) error {
 	r.mtx.RLock()
+	defer r.mtx.RUnlock()
 	// send all users changes in votes so that all players can see the overall result
 	for chatID, messageID := range voteMessages {
-		msg := tgbotapi.NewEditMessageReplyMarkup(
-			chatID,
-			messageID,
-			tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					thumbUpButton(r.activeVote.thumbUp),
-					thumbDownButton(r.activeVote.thumbDown),
-				),
-			),
-		)
-
+		msg := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, r.renderVoteButtons())
 		if _, err := r.tg.Send(msg); err != nil {
 			return fmt.Errorf("send msg: %w", err)
 		}
 	}
-	r.mtx.RUnlock()
+
 	return nil
 }
 
 func (r *Session) sendRoundClosed() {
+	if r.Config.LiveBoard {
+		return
+	}
+
 	r.syncBroadcast(fmt.Sprintf(resource.TextRoundFavoriteMsg, r.CurrRoundIdx+1))
 }
 
@@ -358,6 +373,10 @@ func (r *Session) sendWhoFavoritesMsg() {
 	favorites := r.Favorites()
 
 	r.asyncBroadcast(r.renderGameFavorites(favorites))
+	// everyone is looking at the same phone, so the whole scoreboard is shown
+	if r.Config.HotSeat {
+		r.asyncBroadcast(r.renderScores())
+	}
 }
 
 func (r *Session) sendStartSticker() error {
@@ -427,14 +446,14 @@ func (r *Session) sendChoiceBloopsMsg(ctx context.Context, player *model.Player)
 
 	for i := 0; i < rewardsNum; i++ {
 		if i < treasuresNum {
-			idx := fastrand.Uint32n(uint32(len(resource.Bloopses)))
+			idx := r.rnd.Intn(len(resource.Bloopses))
 			bloops[i] = resource.Bloopses[idx].Name
 		} else {
 			bloops[i] = "Обычный раунд"
 		}
 	}
 
-	rand.Shuffle(len(bloops), func(i, j int) {
+	r.rnd.Shuffle(len(bloops), func(i, j int) {
 		bloops[i], bloops[j] = bloops[j], bloops[i]
 	})
 
@@ -469,7 +488,7 @@ func (r *Session) sendChoiceBloopsMsg(ctx context.Context, player *model.Player)
 		logger := logging.FromContext(ctx).Named("match.sendChoiceBloopsMsg")
 		defer func() {
 			if opened.equal(attempts) {
-				util.Sleep(3 * time.Second)
+				r.clock.Sleep(3 * time.Second)
 
 				r.mtx.Lock()
 				delete(r.msgCallback, output.MessageID)
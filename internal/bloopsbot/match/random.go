@@ -0,0 +1,34 @@
+package match
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// newSeed the seed of a match that is not replayed
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// the source of all the randomness of a match, the same seed gives the same letters, turns and bloopses
+func newRandom(seed int64) *random {
+	return &random{rnd: rand.New(rand.NewSource(seed))} // nolint
+}
+
+type random struct {
+	mtx sync.Mutex
+	rnd *rand.Rand
+}
+
+func (r *random) Intn(n int) int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.rnd.Intn(n)
+}
+
+func (r *random) Shuffle(n int, swap func(i, j int)) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.rnd.Shuffle(n, swap)
+}
@@ -0,0 +1,62 @@
+package match
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/clock"
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+)
+
+func TestSessionReplay(t *testing.T) {
+	t.Parallel()
+
+	newSession := func() *Session {
+		s := NewSession(Config{
+			Code:     1234,
+			Seed:     42,
+			Bloopses: append([]resource.Bloops(nil), resource.Bloopses...),
+			Clock:    clock.NewFake(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		})
+		for i := int64(1); i <= 5; i++ {
+			s.Players = append(s.Players, model.NewPlayer(i, userModel.User{ID: i, FirstName: strconv.FormatInt(i, 10)}, false))
+		}
+
+		return s
+	}
+
+	// the same seed must deal the same turns, dice and bloopses
+	deal := func(s *Session) []string {
+		var out []string
+		for i := 0; i < 20; i++ {
+			player, _ := s.nextPlayer()
+			bloops, _ := s.randBloopses()
+			out = append(out, player.FormatFirstName(), bloops.Name, strconv.FormatBool(s.dice()))
+		}
+
+		return out
+	}
+
+	s1, s2 := newSession(), newSession()
+	if !s1.CreatedAt.Equal(s2.CreatedAt) {
+		t.Errorf("expected the fake clock time, got %v and %v", s1.CreatedAt, s2.CreatedAt)
+	}
+
+	d1, d2 := deal(s1), deal(s2)
+	for i := range d1 {
+		if d1[i] != d2[i] {
+			t.Fatalf("replay diverged at %d: %v != %v", i, d1, d2)
+		}
+	}
+}
+
+func TestSessionGeneratedSeed(t *testing.T) {
+	t.Parallel()
+
+	if s := NewSession(Config{}); s.Config.Seed == 0 {
+		t.Errorf("expected generated seed")
+	}
+}
@@ -0,0 +1,205 @@
+package match
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+)
+
+// the sudden death rounds are limited, the leaders who keep the tie share the first place
+const maxSuddenDeathRounds = 3
+
+// TieBreaker compares the players with the same points, the tie-breakers are applied in the order of the config
+type TieBreaker uint8
+
+const (
+	// fewer seconds used over all turns
+	TieBreakerDuration TieBreaker = iota + 1
+	// more completed turns
+	TieBreakerCompleted
+	// more completed bloopses
+	TieBreakerBloops
+)
+
+var TieBreakers = []TieBreaker{
+	TieBreakerDuration,
+	TieBreakerCompleted,
+	TieBreakerBloops,
+}
+
+func (t TieBreaker) Title() string {
+	switch t {
+	case TieBreakerCompleted:
+		return resource.TextTieBreakerCompleted
+	case TieBreakerBloops:
+		return resource.TextTieBreakerBloops
+	default:
+		return resource.TextTieBreakerDuration
+	}
+}
+
+// ParseTieBreakers the tie-breakers of the serialized settings, the unknown values are dropped
+func ParseTieBreakers(values []uint8) []TieBreaker {
+	breakers := make([]TieBreaker, 0, len(values))
+	for _, v := range values {
+		for _, breaker := range TieBreakers {
+			if TieBreaker(v) == breaker {
+				breakers = append(breakers, breaker)
+			}
+		}
+	}
+
+	return breakers
+}
+
+// SerializeTieBreakers the tie-breakers for the state and the presets
+func SerializeTieBreakers(breakers []TieBreaker) []uint8 {
+	values := make([]uint8, len(breakers))
+	for i, breaker := range breakers {
+		values[i] = uint8(breaker)
+	}
+
+	return values
+}
+
+// TieTitle the short description of the tie rules for the settings
+func TieTitle(breakers []TieBreaker, suddenDeath bool) string {
+	titles := make([]string, 0, len(breakers)+1)
+	for _, breaker := range breakers {
+		titles = append(titles, breaker.Title())
+	}
+
+	if suddenDeath {
+		titles = append(titles, resource.TextSuddenDeath)
+	}
+
+	if len(titles) == 0 {
+		return resource.TextTiePointsOnly
+	}
+
+	return strings.Join(titles, ", ")
+}
+
+// compare negative if a ranks higher than b, zero if the tie-breaker can not separate them
+func (t TieBreaker) compare(a, b PlayerScore) int {
+	switch t {
+	case TieBreakerDuration:
+		switch {
+		case a.TotalDuration < b.TotalDuration:
+			return -1
+		case a.TotalDuration > b.TotalDuration:
+			return 1
+		}
+
+		return 0
+	case TieBreakerCompleted:
+		return b.Completed - a.Completed
+	case TieBreakerBloops:
+		return b.Bloops - a.Bloops
+	default:
+		return 0
+	}
+}
+
+// Rank sorts the scores by points and the tie-breakers, the players separated by nothing share the place
+func Rank(scores []PlayerScore, breakers []TieBreaker) {
+	compare := func(a, b PlayerScore) int {
+		if a.Points != b.Points {
+			return b.Points - a.Points
+		}
+
+		for _, breaker := range breakers {
+			if n := breaker.compare(a, b); n != 0 {
+				return n
+			}
+		}
+
+		return 0
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return compare(scores[i], scores[j]) < 0
+	})
+
+	for i := range scores {
+		scores[i].Place = i + 1
+		if i > 0 && compare(scores[i-1], scores[i]) == 0 {
+			scores[i].Place = scores[i-1].Place
+		}
+	}
+}
+
+// newPlayerScore the score of the first rates of the player, the sudden death is decided by the rates before it
+func newPlayerScore(player *model.Player, ratesNum int) PlayerScore {
+	rates := player.Rates
+	if len(rates) > ratesNum {
+		rates = rates[:ratesNum]
+	}
+
+	score := PlayerScore{
+		Player: *player,
+		Rounds: len(rates),
+	}
+
+	for i, rate := range rates {
+		score.Points += rate.Points
+		if rate.Completed {
+			score.Completed++
+			if rate.Bloops {
+				score.Bloops++
+			}
+		}
+
+		if i == 0 || rate.Duration < score.MinDuration {
+			score.MinDuration = rate.Duration
+		}
+
+		score.TotalDuration += rate.Duration
+	}
+
+	return score
+}
+
+// suddenDeathLeaders the playing leaders tied after the first rates, nil if the first place is not shared
+func (r *Session) suddenDeathLeaders(ratesNum int) map[int64]struct{} {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	scores := make([]PlayerScore, len(r.Players))
+	for i, player := range r.Players {
+		scores[i] = newPlayerScore(player, ratesNum)
+	}
+
+	Rank(scores, r.Config.TieBreakers)
+
+	leaders := map[int64]struct{}{}
+	for _, score := range scores {
+		if score.Place == 1 && score.Player.IsPlaying() {
+			leaders[score.Player.UserID] = struct{}{}
+		}
+	}
+
+	if len(leaders) < 2 {
+		return nil
+	}
+
+	return leaders
+}
+
+// isSuddenDeath the rounds after the last round of the config are played only by the tied leaders
+func (r *Session) isSuddenDeath() bool {
+	return r.CurrRoundIdx >= r.Config.RoundsNum
+}
+
+// startSuddenDeath the last round is over and the leaders are still tied
+func (r *Session) startSuddenDeath() (map[int64]struct{}, bool) {
+	if !r.Config.SuddenDeath || r.CurrRoundIdx+1 >= r.Config.RoundsNum+maxSuddenDeathRounds || r.AlivePlayersLen() == 0 {
+		return nil, false
+	}
+
+	leaders := r.suddenDeathLeaders(r.CurrRoundIdx + 1)
+
+	return leaders, leaders != nil
+}
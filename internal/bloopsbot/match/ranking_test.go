@@ -0,0 +1,116 @@
+package match
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+)
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+
+	newScores := func() []PlayerScore {
+		return []PlayerScore{
+			{Player: model.Player{UserID: 1}, Points: 10, TotalDuration: 40 * time.Second, Completed: 2, Bloops: 0},
+			{Player: model.Player{UserID: 2}, Points: 20, TotalDuration: 50 * time.Second, Completed: 2},
+			{Player: model.Player{UserID: 3}, Points: 10, TotalDuration: 30 * time.Second, Completed: 1, Bloops: 1},
+			{Player: model.Player{UserID: 4}, Points: 10, TotalDuration: 30 * time.Second, Completed: 1, Bloops: 1},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		breakers []TieBreaker
+		order    []int64
+		places   []int
+	}{
+		{name: "points_only", order: []int64{2, 1, 3, 4}, places: []int{1, 2, 2, 2}},
+		{
+			name:     "duration",
+			breakers: []TieBreaker{TieBreakerDuration},
+			order:    []int64{2, 3, 4, 1},
+			places:   []int{1, 2, 2, 4},
+		},
+		{
+			name:     "completed_first",
+			breakers: []TieBreaker{TieBreakerCompleted, TieBreakerDuration},
+			order:    []int64{2, 1, 3, 4},
+			places:   []int{1, 2, 3, 3},
+		},
+		{
+			name:     "bloops",
+			breakers: []TieBreaker{TieBreakerBloops},
+			order:    []int64{2, 3, 4, 1},
+			places:   []int{1, 2, 2, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			scores := newScores()
+			Rank(scores, tc.breakers)
+
+			order := make([]int64, len(scores))
+			places := make([]int, len(scores))
+			for i, score := range scores {
+				order[i], places[i] = score.Player.UserID, score.Place
+			}
+
+			if !reflect.DeepEqual(order, tc.order) || !reflect.DeepEqual(places, tc.places) {
+				t.Errorf("expected %v %v, got %v %v", tc.order, tc.places, order, places)
+			}
+		})
+	}
+}
+
+func TestSessionSuddenDeath(t *testing.T) {
+	t.Parallel()
+
+	s := NewSession(Config{Code: 1234, RoundsNum: 1, SuddenDeath: true})
+	for i, points := range []int{10, 10, 5} {
+		player := model.NewPlayer(int64(i+1), userModel.User{ID: int64(i + 1)}, false)
+		player.Rates = append(player.Rates, &model.Rate{Points: points, Completed: true, Duration: time.Second})
+		s.Players = append(s.Players, player)
+	}
+
+	if favorites := s.Favorites(); len(favorites) != 2 {
+		t.Fatalf("expected two favorites, got %+v", favorites)
+	}
+
+	leaders, ok := s.startSuddenDeath()
+	if !ok || len(leaders) != 2 {
+		t.Fatalf("expected sudden death of two leaders, got %v", leaders)
+	}
+
+	// only the tied leaders play the extra round
+	s.nextRound()
+	for i := 0; i < 2; i++ {
+		player, ok := s.nextPlayer()
+		if _, leader := leaders[player.UserID]; !ok || !leader {
+			t.Fatalf("expected the leader to play, got %+v", player)
+		}
+
+		points := 0
+		if player.UserID == 1 {
+			points = 3
+		}
+		player.Rates = append(player.Rates, &model.Rate{Points: points})
+	}
+
+	if player, ok := s.nextPlayer(); ok {
+		t.Fatalf("expected the extra round to be over, got %+v", player)
+	}
+
+	if _, ok := s.startSuddenDeath(); ok {
+		t.Errorf("expected the tie to be resolved")
+	}
+
+	if favorites := s.Favorites(); len(favorites) != 1 || favorites[0].Player.UserID != 1 {
+		t.Errorf("expected the single favorite, got %+v", favorites)
+	}
+}
@@ -3,6 +3,7 @@ package match
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
 	"github.com/bloops-games/bloops/internal/database/matchstate/model"
@@ -19,6 +20,50 @@ func thumbDownButton(n int) tgbotapi.InlineKeyboardButton {
 	return tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d %s", n, resource.TextThumbDown), resource.TextThumbDown)
 }
 
+func (r *Session) renderVoteButtons() tgbotapi.InlineKeyboardMarkup {
+	if !r.activeVote.mode.isPublic() {
+		return tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(resource.TextThumbUp, resource.TextThumbUp),
+				tgbotapi.NewInlineKeyboardButtonData(resource.TextThumbDown, resource.TextThumbDown),
+			),
+		)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			thumbUpButton(r.activeVote.thumbUp),
+			thumbDownButton(r.activeVote.thumbDown),
+		),
+	)
+}
+
+func (r *Session) renderVoteResult(v *vote, accepted bool) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	_, _ = fmt.Fprintf(
+		buf,
+		"%s Итоги голосования: %s %s / %s %s\n",
+		emoji.Loudspeaker.String(),
+		strconv.Itoa(v.thumbUp),
+		resource.TextThumbUp,
+		strconv.Itoa(v.thumbDown),
+		resource.TextThumbDown,
+	)
+
+	if accepted {
+		buf.WriteString(resource.TextVoteAcceptedMsg)
+	} else {
+		buf.WriteString(resource.TextVoteRejectedMsg)
+	}
+
+	return buf.String()
+}
+
 func (r *Session) renderDropBloopsMsg(bloops *resource.Bloops) string {
 	buf := strpool.Get()
 	defer func() {
@@ -61,7 +106,11 @@ func (r *Session) renderStartMsg() string {
 	_, _ = fmt.Fprintf(buf, "%s %s слов\n", emoji.Pen.String(), strconv.Itoa(len(r.Config.Categories)))
 	_, _ = fmt.Fprintf(buf, "%s %s секунд\n\n", emoji.Stopwatch.String(), strconv.Itoa(r.currRoundSeconds))
 	_, _ = fmt.Fprintf(buf, "%s Категории:\n\n", emoji.CardIndex.String())
-	_, _ = fmt.Fprintf(buf, "%s\n\n%s", r.renderCategories(), resource.TextClickStartBtnMsg)
+	_, _ = fmt.Fprintf(buf, "%s\n\n", r.renderCategories())
+	if r.Config.IsTyped() {
+		_, _ = fmt.Fprintf(buf, "%s\n\n", resource.TextTypeWordsMsg)
+	}
+	buf.WriteString(resource.TextClickStartBtnMsg)
 
 	return buf.String()
 }
@@ -97,26 +146,26 @@ func (r *Session) renderScores() string {
 
 	_, _ = fmt.Fprintf(buf, "%s %s", emoji.Trophy.String(), resource.TextLeaderboardHeader)
 
-	medalIcon := func(n int) string {
+	medalIcon := func(place int) string {
 		var medal string
 
-		if n == 0 {
+		if place == 1 {
 			medal = emoji.FirstPlaceMedal.String()
-		} else if n == 1 {
+		} else if place == 2 {
 			medal = emoji.SecondPlaceMedal.String()
-		} else if n == 2 {
+		} else if place == 3 {
 			medal = emoji.ThirdPlaceMedal.String()
 		}
 
 		return medal
 	}
 
-	for n, cell := range r.Scores() {
+	for _, cell := range r.Scores() {
 		_, _ = fmt.Fprintf(
 			buf,
 			"%s. %s*%s*, %s очков, %s/%s\n",
-			strconv.Itoa(n+1),
-			medalIcon(n),
+			strconv.Itoa(cell.Place),
+			medalIcon(cell.Place),
 			cell.Player.FormatFirstName(),
 			strconv.Itoa(cell.Points),
 			strconv.Itoa(len(cell.Player.Rates)),
@@ -169,10 +218,26 @@ func (r *Session) renderSetting() string {
 	_, _ = fmt.Fprintf(buf, "%s Голосование: ", emoji.Loudspeaker.String())
 
 	if r.Config.Vote {
-		buf.WriteString("да")
+		_, _ = fmt.Fprintf(
+			buf,
+			"%s, %s сек",
+			r.Config.voteMode().Title(),
+			strconv.Itoa(int(r.Config.voteTimeout().Seconds())),
+		)
 	} else {
 		buf.WriteString("нет")
 	}
+	buf.WriteString("\n")
+	_, _ = fmt.Fprintf(buf, "%s Очки: %s", emoji.HundredPoints.String(), r.Config.Scoring.Title())
+	if r.Config.HotSeat {
+		_, _ = fmt.Fprintf(buf, "\n%s Режим: %s", emoji.MobilePhone.String(), resource.TextModeHotSeat)
+	}
+	if len(r.Config.TieBreakers) > 0 || r.Config.SuddenDeath {
+		_, _ = fmt.Fprintf(buf, "\n%s Ничья: %s", emoji.BalanceScale.String(), r.Config.tieTitle())
+	}
+	if r.Config.LiveBoard {
+		_, _ = fmt.Fprintf(buf, "\n%s %s", emoji.Pushpin.String(), resource.TextStageBoard)
+	}
 
 	buf.WriteString("\n\n")
 	_, _ = fmt.Fprintf(buf, "%s Категории\n", emoji.CardIndex.String())
@@ -211,6 +276,28 @@ func (r *Session) renderPlayerGetPoints(player *model.Player, points int) string
 	return buf.String()
 }
 
+func (r *Session) renderSuddenDeathMsg(leaders map[int64]struct{}) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	names := make([]string, 0, len(leaders))
+	for _, player := range r.Players {
+		if _, ok := leaders[player.UserID]; ok {
+			names = append(names, "*"+player.FormatFirstName()+"*")
+		}
+	}
+
+	_, _ = fmt.Fprintf(buf, resource.TextSuddenDeathMsg, strings.Join(names, ", "))
+
+	return buf.String()
+}
+
 func (r *Session) renderStartHelpMsg(player *model.Player, sentLetter string) string {
 	buf := strpool.Get()
 	defer func() {
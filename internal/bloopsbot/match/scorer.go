@@ -0,0 +1,161 @@
+package match
+
+import (
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+)
+
+type ScoringKind uint8
+
+// zero value is treated as ScoringKindSeconds, so states serialized before scoring rules existed restore unchanged
+const (
+	ScoringKindSeconds ScoringKind = iota + 1
+	ScoringKindFlat
+	ScoringKindTiers
+	ScoringKindWords
+)
+
+var ScoringKinds = []ScoringKind{
+	ScoringKindSeconds,
+	ScoringKindFlat,
+	ScoringKindTiers,
+	ScoringKindWords,
+}
+
+func (k ScoringKind) Title() string {
+	switch k {
+	case ScoringKindFlat:
+		return resource.TextScoringFlat
+	case ScoringKindTiers:
+		return resource.TextScoringTiers
+	case ScoringKindWords:
+		return resource.TextScoringWords
+	default:
+		return resource.TextScoringSeconds
+	}
+}
+
+const (
+	flatRoundPoints = 20
+	wordPoints      = 3
+)
+
+// time bonus tiers, share of the round time left -> points
+var defaultTimeTiers = []timeTier{
+	{share: 0.66, points: 30},
+	{share: 0.33, points: 20},
+	{share: 0, points: 10},
+}
+
+// RoundResult is everything the scorer knows about the finished turn
+type RoundResult struct {
+	// seconds left on the timer when the player pushed stop
+	Seconds int
+	// full turn time including the bloops bonus seconds
+	RoundTime int
+	// points of the dropped bloops, zero if there was no bloops
+	BloopsPoints int
+	// number of words typed by the player in typed modes
+	Words int
+}
+
+// Completed the player managed to stop the timer before the time was over
+func (r RoundResult) Completed() bool {
+	return r.Seconds > 0
+}
+
+type Scorer interface {
+	Score(result RoundResult) int
+}
+
+func NewScorer(kind ScoringKind) Scorer {
+	switch kind {
+	case ScoringKindFlat:
+		return flatScorer{points: flatRoundPoints}
+	case ScoringKindTiers:
+		return tiersScorer{tiers: defaultTimeTiers}
+	case ScoringKindWords:
+		return wordsScorer{points: wordPoints}
+	default:
+		return secondsScorer{}
+	}
+}
+
+// remaining seconds become points, bloops points only if the round is completed
+type secondsScorer struct{}
+
+func (secondsScorer) Score(result RoundResult) int {
+	if !result.Completed() {
+		return result.Seconds
+	}
+
+	return result.Seconds + result.BloopsPoints
+}
+
+// the same points for every completed round regardless of the time
+type flatScorer struct {
+	points int
+}
+
+func (s flatScorer) Score(result RoundResult) int {
+	if !result.Completed() {
+		return 0
+	}
+
+	return s.points + result.BloopsPoints
+}
+
+type timeTier struct {
+	share  float64
+	points int
+}
+
+// the faster the player, the higher the tier
+type tiersScorer struct {
+	tiers []timeTier
+}
+
+func (s tiersScorer) Score(result RoundResult) int {
+	if !result.Completed() || result.RoundTime <= 0 {
+		return 0
+	}
+
+	share := float64(result.Seconds) / float64(result.RoundTime)
+	for _, tier := range s.tiers {
+		if share > tier.share {
+			return tier.points + result.BloopsPoints
+		}
+	}
+
+	return result.BloopsPoints
+}
+
+// points for every typed word, the time only decides whether the bloops is counted
+type wordsScorer struct {
+	points int
+}
+
+func (s wordsScorer) Score(result RoundResult) int {
+	points := result.Words * s.points
+	if result.Completed() {
+		points += result.BloopsPoints
+	}
+
+	return points
+}
+
+// splitting the typed message into words, one message may contain several lines or comma separated words,
+// the words are lowercased to count the repeated ones once
+func splitWords(text string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\n' || r == ','
+	}) {
+		if word = strings.Join(strings.Fields(word), " "); word != "" {
+			words = append(words, strings.ToLower(word))
+		}
+	}
+
+	return words
+}
@@ -0,0 +1,119 @@
+package match
+
+import "testing"
+
+func TestScorers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		kind     ScoringKind
+		result   RoundResult
+		expected int
+	}{
+		{
+			name:     "seconds_completed_with_bloops",
+			kind:     ScoringKindSeconds,
+			result:   RoundResult{Seconds: 12, RoundTime: 30, BloopsPoints: 10},
+			expected: 22,
+		},
+		{
+			name:     "seconds_time_over",
+			kind:     ScoringKindSeconds,
+			result:   RoundResult{Seconds: 0, RoundTime: 30, BloopsPoints: 10},
+			expected: 0,
+		},
+		{
+			name:     "zero_kind_is_seconds",
+			kind:     0,
+			result:   RoundResult{Seconds: 5, RoundTime: 30},
+			expected: 5,
+		},
+		{
+			name:     "flat_completed",
+			kind:     ScoringKindFlat,
+			result:   RoundResult{Seconds: 1, RoundTime: 30, BloopsPoints: 5},
+			expected: flatRoundPoints + 5,
+		},
+		{
+			name:     "flat_time_over",
+			kind:     ScoringKindFlat,
+			result:   RoundResult{RoundTime: 30},
+			expected: 0,
+		},
+		{
+			name:     "tiers_fast",
+			kind:     ScoringKindTiers,
+			result:   RoundResult{Seconds: 25, RoundTime: 30},
+			expected: 30,
+		},
+		{
+			name:     "tiers_middle",
+			kind:     ScoringKindTiers,
+			result:   RoundResult{Seconds: 15, RoundTime: 30},
+			expected: 20,
+		},
+		{
+			name:     "tiers_slow",
+			kind:     ScoringKindTiers,
+			result:   RoundResult{Seconds: 2, RoundTime: 30},
+			expected: 10,
+		},
+		{
+			name:     "words_time_over",
+			kind:     ScoringKindWords,
+			result:   RoundResult{RoundTime: 30, BloopsPoints: 10, Words: 4},
+			expected: 4 * wordPoints,
+		},
+		{
+			name:     "words_completed_with_bloops",
+			kind:     ScoringKindWords,
+			result:   RoundResult{Seconds: 3, RoundTime: 30, BloopsPoints: 10, Words: 4},
+			expected: 4*wordPoints + 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewScorer(tc.kind).Score(tc.result); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	t.Parallel()
+
+	words := splitWords("Москва, малина\nМаша ,  москва, Красная  площадь")
+	expected := []string{"москва", "малина", "маша", "москва", "красная площадь"}
+	if len(words) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, words)
+	}
+
+	for i := range expected {
+		if words[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], words[i])
+		}
+	}
+}
+
+func TestTypedWords(t *testing.T) {
+	t.Parallel()
+
+	s := NewSession(Config{Categories: []string{"Города", "Реки", "Имена"}})
+	s.startTyping(1)
+	s.typeWords("а, А, а\nа")
+	s.typeWords("Москва")
+	if words := s.stopTyping(); words != 2 {
+		t.Errorf("expected the repeated words to count once, got %d", words)
+	}
+
+	s.startTyping(1)
+	s.typeWords("Москва, Волга, Маша, Пермь, Ока")
+	if words := s.stopTyping(); words != 3 {
+		t.Errorf("expected the words capped at the categories, got %d", words)
+	}
+}
@@ -5,19 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"math/rand"
 	"runtime"
-	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
-	"github.com/bloops-games/bloops/internal/bloopsbot/util"
+	"github.com/bloops-games/bloops/internal/clock"
 	"github.com/bloops-games/bloops/internal/database/matchstate/model"
 	"github.com/bloops-games/bloops/internal/logging"
+	"github.com/bloops-games/bloops/internal/webhook"
 	"github.com/enescakir/emoji"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"github.com/valyala/fastrand"
 )
 
 const bloopsMaxWeight = 3
@@ -41,12 +40,10 @@ const (
 var (
 	ErrContextFatalClosed = fmt.Errorf("context closed")
 	ErrValidation         = fmt.Errorf("validation errors")
+	ErrPlayerNotFound     = fmt.Errorf("player not found")
+	ErrNotGuestOwner      = fmt.Errorf("only the owner can remove the guest")
 )
 
-func newVote() *vote {
-	return &vote{pub: make(chan struct{}, 1)}
-}
-
 type PlayerScore struct {
 	Player        model.Player
 	Points        int
@@ -54,15 +51,25 @@ type PlayerScore struct {
 	MinDuration   time.Duration
 	Completed     int
 	Rounds        int
-}
-
-type vote struct {
-	thumbUp   int
-	thumbDown int
-	pub       chan struct{}
+	// completed bloopses
+	Bloops int
+	// shared by the players the tie-breakers can not separate
+	Place int
 }
 
 func NewSession(config Config) *Session {
+	if config.ViewToken == "" {
+		config.ViewToken = newViewToken()
+	}
+
+	if config.Seed == 0 {
+		config.Seed = newSeed()
+	}
+
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+
 	return &Session{
 		Config:      config,
 		tg:          config.Tg,
@@ -71,13 +78,20 @@ func NewSession(config Config) *Session {
 		sndCh:       make(chan tgbotapi.Chattable, 10),
 		startCh:     make(chan struct{}, 1),
 		stopCh:      make(chan struct{}, 1),
+		done:        make(chan struct{}),
 		passCh:      make(chan int64, 1),
+		voteSema:    make(chan struct{}, 1),
+		ctx:         context.Background(),
 		State:       StateKindWaiting,
 		msgCallback: map[int]QueryCallbackHandlerFn{},
 		doneFn:      config.DoneFn,
 		warnFn:      config.WarnFn,
 		timeout:     config.Timeout,
-		CreatedAt:   time.Now(),
+		scorer:      NewScorer(config.Scoring),
+		viewSubs:    map[chan struct{}]struct{}{},
+		clock:       config.Clock,
+		rnd:         newRandom(config.Seed),
+		CreatedAt:   config.Clock.Now(),
 	}
 }
 
@@ -99,10 +113,17 @@ type Session struct {
 	currRoundSeconds int
 	bloopsPoints     int
 
+	scorer Scorer
+	// the player whose typed words are counted in the typed modes
+	typingUserID int64
+	typedWords   map[string]struct{}
+
 	timeout time.Duration
+	clock   clock.Clock
+	rnd     *random
 
-	doneFn func(session *Session) error
-	warnFn func(session *Session) error
+	doneFn func(ctx context.Context, session *Session) error
+	warnFn func(ctx context.Context, session *Session) error
 	cancel func()
 
 	sndCh      chan tgbotapi.Chattable
@@ -111,6 +132,23 @@ type Session struct {
 	passCh     chan int64
 	sema       sync.Once
 	activeVote *vote
+	done       chan struct{}
+
+	// the stats of the finished match are being written, the points can not be corrected anymore
+	closing bool
+	// one vote at a time, the turn vote waits for the vote re-opened by the host
+	voteSema chan struct{}
+	// the context of the match for the re-opened votes
+	ctx context.Context
+
+	// live board mode, the messages of the current turn to be deleted after it
+	transient []transientMessage
+
+	// presenter screen
+	turn       turnView
+	viewMtx    sync.Mutex
+	viewSubs   map[chan struct{}]struct{}
+	viewClosed bool
 }
 
 func (r *Session) Stop() {
@@ -120,25 +158,29 @@ func (r *Session) Stop() {
 func (r *Session) Run(ctx context.Context) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	r.cancel = cancel
+	r.mtx.Lock()
+	r.ctx = ctx
+	r.mtx.Unlock()
 	logger := logging.FromContext(ctx)
 	r.sema.Do(func() {
 		go r.loop(ctx)
 		go r.sendingPool(ctx)
+		if r.Config.LiveBoard {
+			go r.boardLoop(ctx)
+		}
 	})
-	logger.Infof("The game session created, code: %d, author: %s", r.Config.Code, r.Config.AuthorName)
+	logger.Infof("The game session created, code: %d, author: %s, seed: %d", r.Config.Code, r.Config.AuthorName, r.Config.Seed)
 }
 
+// Favorites the players on the first place
 func (r *Session) Favorites() []PlayerScore {
 	var favorites []PlayerScore
-	var max int
-
-	scores := r.Scores()
-	for _, score := range scores {
-		if score.Points >= max {
-			max = score.Points
+	for _, score := range r.Scores() {
+		if score.Place == 1 {
 			favorites = append(favorites, score)
 		}
 	}
+
 	return favorites
 }
 
@@ -148,8 +190,9 @@ func (r *Session) MoveState(kind uint8) {
 
 func (r *Session) ChangeState(kind uint8) {
 	r.mtx.Lock()
-	defer r.mtx.Unlock()
 	r.State = kind
+	r.mtx.Unlock()
+	r.publishView()
 }
 
 func (r *Session) AlivePlayersLen() int {
@@ -228,6 +271,11 @@ func (r *Session) executeMessageQuery(userID int64, query *tgbotapi.Message) err
 		}
 	}
 
+	// in the typed modes the active player's messages are the answers
+	if !resource.IsKeyboardButtonText(query.Text) && r.isTyping(userID) {
+		r.typeWords(query.Text)
+	}
+
 	return nil
 }
 
@@ -269,6 +317,11 @@ func (r *Session) loop(ctx context.Context) {
 				logger.Infof("Change state to finished %d, author: %s", r.Config.Code, r.Config.AuthorName)
 				r.sendWhoFavoritesMsg()
 				logger.Infof("Send favorites %d, author: %s", r.Config.Code, r.Config.AuthorName)
+				if r.Config.FinishFn != nil {
+					if err := r.Config.FinishFn(ctx, r); err != nil {
+						logger.Errorf("finish fn: %v", err)
+					}
+				}
 				logger.Infof("The game session is complete %d, author: %s", r.Config.Code, r.Config.AuthorName)
 			case StateKindProcessing:
 				logger.Infof(
@@ -277,13 +330,23 @@ func (r *Session) loop(ctx context.Context) {
 					r.Config.AuthorName,
 				)
 				r.ChangeState(StateKindProcessing)
+				r.emit(webhook.EventRoundClosed, r.webhookRound())
 				logger.Infof(
 					"Change state to processing %d, author: %s",
 					r.Config.Code,
 					r.Config.AuthorName,
 				)
 
-				if r.Config.RoundsNum == r.CurrRoundIdx+1 {
+				if r.CurrRoundIdx+1 >= r.Config.RoundsNum {
+					if leaders, ok := r.startSuddenDeath(); ok {
+						r.syncBroadcast(r.renderSuddenDeathMsg(leaders))
+						logger.Infof("Sudden death %d, author: %s, leaders: %d", r.Config.Code, r.Config.AuthorName, len(leaders))
+						r.clock.Sleep(3 * time.Second)
+						r.nextRound()
+						r.stateCh <- StateKindPlaying
+						break
+					}
+
 					r.stateCh <- StateKindFinished
 					break
 				}
@@ -299,11 +362,15 @@ func (r *Session) loop(ctx context.Context) {
 					r.Config.Code,
 					r.Config.AuthorName,
 				)
-				util.Sleep(3 * time.Second)
+				r.clock.Sleep(3 * time.Second)
 				r.nextRound()
 				r.stateCh <- StateKindPlaying
 			case StateKindPlaying:
+				started := r.getState() == StateKindWaiting
 				r.ChangeState(StateKindPlaying)
+				if started {
+					r.emit(webhook.EventMatchStarted, r.webhookMatch())
+				}
 				logger.Infof("The game %d changed its State to playing, author: %s", r.Config.Code, r.Config.AuthorName)
 				if err := r.playing(ctx); err != nil {
 					if !errors.Is(err, ErrContextFatalClosed) {
@@ -349,9 +416,11 @@ func (r *Session) shutdown(ctx context.Context) {
 		close(r.passCh)
 		close(r.stopCh)
 		close(r.stateCh)
+		r.closeView()
+		close(r.done)
 	}()
 
-	if time.Since(r.CreatedAt) <= r.timeout {
+	if r.clock.Since(r.CreatedAt) <= r.timeout {
 		if r.getState() != StateKindFinished {
 			r.mtx.RLock()
 		OuterLoop:
@@ -370,14 +439,17 @@ func (r *Session) shutdown(ctx context.Context) {
 
 			r.mtx.RUnlock()
 
-			if err := r.warnFn(r); err != nil {
+			if err := r.warnFn(ctx, r); err != nil {
 				logger.Errorf("done function: %v", err)
 			}
 
 			return
 		}
 
-		if err := r.doneFn(r); err != nil {
+		r.mtx.Lock()
+		r.closing = true
+		r.mtx.Unlock()
+		if err := r.doneFn(ctx, r); err != nil {
 			logger.Errorf("done function: %v", err)
 		}
 	}
@@ -400,25 +472,32 @@ PlayerLoop:
 
 		r.currRoundSeconds = r.Config.RoundTime
 		r.bloopsPoints = 0
+		r.setTurn(turnView{player: player.FormatFirstName()})
+
+		// send "next player" asyncBroadcast message, the live board shows the player itself
+		switch {
+		case r.Config.HotSeat:
+			r.syncBroadcast(fmt.Sprintf(resource.TextHotSeatPassMsg, player.FormatFirstName()))
+		case !r.Config.LiveBoard:
+			r.syncBroadcast(fmt.Sprintf(resource.TextNextPlayerMsg, player.FormatFirstName()))
+		}
 
-		// send "next player" asyncBroadcast message
-		nextPlayerMsg := fmt.Sprintf(resource.TextNextPlayerMsg, player.FormatFirstName())
-		r.syncBroadcast(nextPlayerMsg)
-
-		util.Sleep(2 * time.Second)
+		r.clock.Sleep(2 * time.Second)
 		if r.Config.IsBloops() {
 			logger.Infof("Checking bloops, game session %d, author: %s", r.Config.Code, r.Config.AuthorName)
 			msg := tgbotapi.NewMessage(player.ChatID, "Проверяем, выпадет ли блюпс?")
-			if _, err := r.tg.Send(msg); err != nil {
+			output, err := r.tg.Send(msg)
+			if err != nil {
 				return fmt.Errorf("send msg: %w", err)
 			}
 
+			r.keepTransient(player.ChatID, output.MessageID)
 			messageID, err := r.checkBloopsSendMsg(player)
 			if err != nil {
 				return fmt.Errorf("send ready set go for bloopses: %w", err)
 			}
 
-			if r.dice() {
+			if nextBloops, ok := r.rollBloops(); ok {
 				logger.Infof(
 					"The bloops dropped for %s, game session %d, author: %s",
 					player.User.FirstName,
@@ -432,9 +511,6 @@ PlayerLoop:
 					return fmt.Errorf("send msg: %w", err)
 				}
 
-				nextBloops, _ := r.randBloopses()
-				r.bloopsPoints = nextBloops.Points
-				r.currRoundSeconds = r.Config.RoundTime + nextBloops.Seconds
 				bloops := &nextBloops
 				rate.BloopsName = bloops.Name
 
@@ -450,8 +526,8 @@ PlayerLoop:
 					r.Config.AuthorName,
 				)
 
-				timerFatal := time.NewTimer(defaultInactiveFatalTime * time.Second)
-				timerWarn := time.NewTimer(defaultInactiveWarnTime * time.Second)
+				timerFatal := r.clock.NewTimer(defaultInactiveFatalTime * time.Second)
+				timerWarn := r.clock.NewTimer(defaultInactiveWarnTime * time.Second)
 			ChallengeNext:
 				for {
 					select {
@@ -459,21 +535,21 @@ PlayerLoop:
 						timerWarn.Stop()
 						timerFatal.Stop()
 						break ChallengeNext
-					case <-timerWarn.C:
+					case <-timerWarn.C():
 						timerWarn.Stop()
 						r.syncBroadcast(fmt.Sprintf(
 							"Игрок %s должен нажать на кнопку Понятно в течение %d сек",
 							player.FormatFirstName(),
 							defaultInactiveFatalTime-defaultInactiveWarnTime,
 						))
-					case <-timerFatal.C:
+					case <-timerFatal.C():
 						timerFatal.Stop()
 						r.syncBroadcast(fmt.Sprintf(
 							"%s не начал раунд в течение %d сек, он пропускает ход",
 							player.FormatFirstName(),
 							defaultInactiveFatalTime,
 						))
-						r.RemovePlayer(player.UserID)
+						r.dropInactive(player)
 						continue PlayerLoop
 					case <-ctx.Done():
 						return ErrContextFatalClosed
@@ -488,7 +564,8 @@ PlayerLoop:
 				if _, err := r.tg.Send(msg); err != nil {
 					return fmt.Errorf("send msg: %w", err)
 				}
-				util.Sleep(1 * time.Second)
+				r.keepTransient(player.ChatID, messageID)
+				r.clock.Sleep(1 * time.Second)
 			}
 		}
 		logger.Infof(
@@ -502,8 +579,8 @@ PlayerLoop:
 			return fmt.Errorf("send start msg: %w", err)
 		}
 
-		timerFatal := time.NewTimer(defaultInactiveFatalTime * time.Second)
-		timerWarn := time.NewTimer(defaultInactiveWarnTime * time.Second)
+		timerFatal := r.clock.NewTimer(defaultInactiveFatalTime * time.Second)
+		timerWarn := r.clock.NewTimer(defaultInactiveWarnTime * time.Second)
 	SessionStart:
 		for {
 			select {
@@ -511,21 +588,21 @@ PlayerLoop:
 				timerWarn.Stop()
 				timerFatal.Stop()
 				break SessionStart
-			case <-timerWarn.C:
+			case <-timerWarn.C():
 				timerWarn.Stop()
 				r.syncBroadcast(fmt.Sprintf(
 					"Игрок %s должен нажать на кнопку старта в течение %d сек",
 					player.FormatFirstName(),
 					defaultInactiveFatalTime-defaultInactiveWarnTime,
 				))
-			case <-timerFatal.C:
+			case <-timerFatal.C():
 				timerFatal.Stop()
 				r.syncBroadcast(fmt.Sprintf(
 					"%s не начал раунд в течение %d сек, он пропускает ход",
 					player.FormatFirstName(),
 					defaultInactiveFatalTime,
 				))
-				r.RemovePlayer(player.UserID)
+				r.dropInactive(player)
 				continue PlayerLoop
 			case <-ctx.Done():
 				return ErrContextFatalClosed
@@ -543,10 +620,15 @@ PlayerLoop:
 			r.Config.AuthorName,
 		)
 		//  generating the letter that the words begin with
-		if err := r.sendLetterMsg(player); err != nil {
+		letter, err := r.sendLetterMsg(player)
+		if err != nil {
 			return fmt.Errorf("generate and send letter msg: %w", err)
 		}
 
+		rate.Letter = letter
+		rate.Categories = make([]string, len(r.Config.Categories))
+		copy(rate.Categories, r.Config.Categories)
+
 		logger.Infof(
 			"Sending letter for player %s, Game session %d, author: %s",
 			player.User.FirstName,
@@ -572,8 +654,14 @@ PlayerLoop:
 			player.User.FirstName,
 		)
 
+		if r.Config.IsTyped() {
+			// the guest's answers are typed on the owner's phone
+			r.startTyping(player.ControllerID())
+		}
+
 		// create ticker. Update player timer every 1sec
 		secs, timeSince, err := r.ticker(ctx, player)
+		rate.Words = r.stopTyping()
 		if err != nil {
 			return fmt.Errorf("ticker: %w", err)
 		}
@@ -585,14 +673,15 @@ PlayerLoop:
 			player.User.FirstName,
 		)
 
-		var reward int
-		if secs > 0 {
-			reward = r.bloopsPoints
+		result := RoundResult{
+			Seconds:      secs,
+			RoundTime:    r.currRoundSeconds,
+			BloopsPoints: r.bloopsPoints,
+			Words:        rate.Words,
 		}
 
-		rate.Duration = time.Since(timeSince)
-		rate.Points = secs + reward
-		rate.Completed = secs > 0
+		rate.Duration = r.clock.Since(timeSince)
+		r.scoreTurn(rate, result)
 		logger.Infof(
 			"Game session %d, author: %s, player get a %d points",
 			r.Config.Code,
@@ -617,7 +706,7 @@ PlayerLoop:
 				)
 				r.syncBroadcast("Игрок не успел справиться с заданием, голосование отменено")
 			} else {
-				if err := r.votes(ctx, rate); err != nil {
+				if err := r.votes(ctx, player, rate); err != nil {
 					return fmt.Errorf("votes: %w", err)
 				}
 			}
@@ -627,19 +716,7 @@ PlayerLoop:
 			return fmt.Errorf("send sticker: %w", err)
 		}
 
-		r.mtx.Lock()
-		player.Rates = append(player.Rates, rate)
-
-		//  remove the bloops that played
-		if rate.Points > 0 && rate.BloopsName != "" {
-			for idx, bloops := range r.Config.Bloopses {
-				if bloops.Name == rate.BloopsName {
-					r.Config.Bloopses = append(r.Config.Bloopses[:idx], r.Config.Bloopses[idx+1:]...)
-				}
-			}
-		}
-
-		r.mtx.Unlock()
+		r.appendRate(player, rate)
 
 		logger.Infof(
 			"Game session %d, author: %s, rate append for player %s",
@@ -647,7 +724,7 @@ PlayerLoop:
 			r.Config.AuthorName,
 			player.User.FirstName,
 		)
-		util.Sleep(2 * time.Second)
+		r.clock.Sleep(2 * time.Second)
 		// send data on the round players
 		r.sndCh <- tgbotapi.NewMessage(player.ChatID, fmt.Sprintf(resource.TextStopPlayerRoundMsg, rate.Points))
 		logger.Infof(
@@ -656,8 +733,13 @@ PlayerLoop:
 			r.Config.AuthorName,
 			player.User.FirstName,
 		)
-		r.asyncBroadcast(r.renderPlayerGetPoints(player, rate.Points), player.UserID)
-		util.Sleep(5 * time.Second)
+		if r.Config.LiveBoard {
+			r.cleanTransient(ctx)
+		} else {
+			r.asyncBroadcast(r.renderPlayerGetPoints(player, rate.Points), player.UserID)
+		}
+		r.publishView()
+		r.clock.Sleep(5 * time.Second)
 	}
 }
 
@@ -668,6 +750,7 @@ func (r *Session) ticker(ctx context.Context, player *model.Player) (int, time.T
 	if err != nil {
 		return 0, time.Time{}, fmt.Errorf("send timer msg: %w", err)
 	}
+	r.keepTransient(player.ChatID, messageID)
 
 	// register stop button handler
 	r.registerCbHandler(messageID, func(query *tgbotapi.CallbackQuery) error {
@@ -684,8 +767,9 @@ func (r *Session) ticker(ctx context.Context, player *model.Player) (int, time.T
 
 		return nil
 	})
-	since := time.Now()
-	ticker := time.NewTicker(1 * time.Second)
+	r.setTurnSeconds(secs)
+	since := r.clock.Now()
+	ticker := r.clock.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 OuterLoop:
 	for {
@@ -698,9 +782,10 @@ OuterLoop:
 			}
 		case <-r.stopCh:
 			break OuterLoop
-		case <-ticker.C:
+		case <-ticker.C():
 			// subtract 1 second each tick
 			secs--
+			r.setTurnSeconds(secs)
 
 			// updating timer
 			if err := r.sendWorkingTimerMsg(player, messageID, secs); err != nil {
@@ -716,35 +801,89 @@ OuterLoop:
 	return secs, since, nil
 }
 
-func (r *Session) votes(ctx context.Context, rate *model.Rate) error {
-	// create new active vote
-	r.activeVote = newVote()
+func (r *Session) votes(ctx context.Context, player *model.Player, rate *model.Rate) error {
+	// the re-opened vote of the host is finished first
+	select {
+	case r.voteSema <- struct{}{}:
+	case <-ctx.Done():
+		return ErrContextFatalClosed
+	}
+	defer func() { <-r.voteSema }()
 
 	// for storing the message id
 	voteMessages := map[int64]int{}
+	accepted, ok, err := r.runVote(ctx, player, "", voteMessages)
+	for chatID, messageID := range voteMessages {
+		r.keepTransient(chatID, messageID)
+	}
+
+	if err != nil || !ok {
+		return err
+	}
+
+	if !accepted {
+		r.mtx.Lock()
+		rate.Points = 0
+		rate.Completed = false
+		r.mtx.Unlock()
+		r.publishView()
+	}
+
+	return nil
+}
+
+// runVote the vote on the answer of the player, not voted if there is nobody to vote
+func (r *Session) runVote(
+	ctx context.Context,
+	player *model.Player,
+	subject string,
+	voteMessages map[int64]int,
+) (accepted bool, voted bool, err error) {
+	voters := r.voters(player)
+	if len(voters) == 0 {
+		return false, false, nil
+	}
+
+	// create new active vote
+	r.mtx.Lock()
+	r.activeVote = newVote(r.Config.voteMode(), voters, r.Config.voteTimeout())
+	activeVote := r.activeVote
+	r.mtx.Unlock()
+	r.publishView()
+
+	// deleting all vote callbacks, the buttons of the closed vote answer nothing
+	defer func() {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		for _, messageID := range voteMessages {
+			delete(r.msgCallback, messageID)
+		}
+	}()
 
 	// send vote buttons and register callbacks
-	if err := r.sendVotesMsg(voteMessages); err != nil {
-		return fmt.Errorf("broadcast vote buttons and register msgCallback: %w", err)
+	if err := r.sendVotesMsg(voteMessages, subject); err != nil {
+		return false, false, fmt.Errorf("broadcast vote buttons and register msgCallback: %w", err)
 	}
 
-	timer := time.NewTimer(defaultInactiveVoteTime * time.Second)
+	timer := r.clock.NewTimer(activeVote.timeout)
 	defer timer.Stop()
-	defer close(r.activeVote.pub)
 
 VoteLoop:
 	for {
 		select {
 		case <-ctx.Done():
-			return ErrContextFatalClosed
-		case <-timer.C:
+			return false, false, ErrContextFatalClosed
+		case <-timer.C():
 			break VoteLoop
-		case <-r.activeVote.pub:
+		case <-activeVote.pub:
+			r.publishView()
 			// updating data in the voting buttons
-			if err := r.sendChangingVotesMsg(voteMessages); err != nil {
-				return fmt.Errorf("broadcast votes: %w", err)
+			if activeVote.mode.isPublic() {
+				if err := r.sendChangingVotesMsg(voteMessages); err != nil {
+					return false, false, fmt.Errorf("broadcast votes: %w", err)
+				}
 			}
-			//  if all active players have voted, then we finish processing the votes
+			//  if all voters have voted, then we finish processing the votes
 			if r.didEveryoneVote() {
 				break VoteLoop
 			}
@@ -752,86 +891,99 @@ VoteLoop:
 	}
 
 	r.mtx.Lock()
-	defer r.mtx.Unlock()
-	// deleting all vote callbacks
-	for _, messageID := range voteMessages {
-		delete(r.msgCallback, messageID)
-	}
+	activeVote.close()
+	accepted = activeVote.accepted()
+	r.mtx.Unlock()
+	r.publishView()
 
-	if r.activeVote.thumbUp < r.activeVote.thumbDown {
-		rate.Points = 0
-		rate.Completed = false
+	if !activeVote.mode.isPublic() {
+		r.syncBroadcast(r.renderVoteResult(activeVote, accepted))
 	}
 
-	return nil
+	return accepted, true, nil
 }
 
-// Calculating the player rating
-func (r *Session) Scores() []PlayerScore {
+// users who can vote for the player's answer
+func (r *Session) voters(player *model.Player) []int64 {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
-	scores := make([]PlayerScore, len(r.Players))
-	for i, player := range r.Players {
-		playerScore := PlayerScore{
-			Player: *player,
-			Rounds: len(player.Rates),
+	mode := r.Config.voteMode()
+
+	var voters []int64
+	for _, p := range r.Players {
+		if !p.IsPlaying() || p.Offline {
+			continue
 		}
 
-		for _, rate := range player.Rates {
-			playerScore.Points += rate.Points
-			if rate.Completed {
-				playerScore.Completed++
+		switch mode {
+		case VoteModeAll:
+		case VoteModeHost:
+			if p.UserID != r.Config.AuthorID {
+				continue
 			}
-
-			if rate.Duration < playerScore.MinDuration {
-				playerScore.MinDuration = rate.Duration
+		default:
+			// the owner does not vote for the answer typed on its own phone
+			if p.UserID == player.ControllerID() {
+				continue
 			}
-
-			playerScore.TotalDuration += rate.Duration
 		}
 
-		scores[i] = playerScore
+		voters = append(voters, p.UserID)
 	}
 
-	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].Points > scores[j].Points
-	})
+	return voters
+}
+
+// Calculating the player rating
+func (r *Session) Scores() []PlayerScore {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	scores := make([]PlayerScore, len(r.Players))
+	for i, player := range r.Players {
+		scores[i] = newPlayerScore(player, len(player.Rates))
+	}
+
+	Rank(scores, r.Config.TieBreakers)
 
 	return scores
 }
 
 //  Select a player who hasn't played in this round yet
 func (r *Session) nextPlayer() (*model.Player, bool) {
+	var leaders map[int64]struct{}
+	if r.isSuddenDeath() {
+		leaders = r.suddenDeathLeaders(r.CurrRoundIdx)
+	}
+
 	var players []*model.Player
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
 	for _, player := range r.Players {
-		if player.IsPlaying() && len(player.Rates) <= r.CurrRoundIdx {
-			players = append(players, player)
+		if !player.IsPlaying() || len(player.Rates) > r.CurrRoundIdx {
+			continue
 		}
+
+		if _, ok := leaders[player.UserID]; r.isSuddenDeath() && !ok {
+			continue
+		}
+
+		players = append(players, player)
 	}
 
 	if len(players) == 0 {
 		return nil, false
 	}
 
-	rnd := fastrand.Uint32n(uint32(len(players)))
-	return players[rnd], true
+	return players[r.rnd.Intn(len(players))], true
 }
 
 func (r *Session) didEveryoneVote() bool {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
-	var playersNum int
-	for _, player := range r.Players {
-		if player.IsPlaying() && !player.Offline {
-			playersNum++
-		}
-	}
-
-	return r.activeVote.thumbUp+r.activeVote.thumbDown == playersNum
+	return r.activeVote.didEveryoneVote()
 }
 
 func (r *Session) findPlayer(userID int64) (*model.Player, bool) {
@@ -851,6 +1003,7 @@ func (r *Session) AddPlayer(player *model.Player) error {
 	if player, ok := r.addPlayer(player); ok {
 		registerPlayerMsg := fmt.Sprintf(resource.TextPlayerJoinedGameMsg, player.FormatFirstName())
 		r.asyncBroadcast(registerPlayerMsg, player.UserID)
+		r.emit(webhook.EventPlayerJoined, r.webhookPlayer(player))
 	}
 
 	return nil
@@ -863,9 +1016,10 @@ func (r *Session) addPlayer(player *model.Player) (*model.Player, bool) {
 
 	for _, p := range r.Players {
 		if p.ChatID == player.ChatID && p.UserID == player.UserID && p.FormatFirstName() == player.FormatFirstName() {
-			if player.State == model.PlayerStateKindLeaving {
-				player.State = model.PlayerStateKindPlaying
-				return nil, true
+			// the player who left comes back with the rates played before
+			if p.State == model.PlayerStateKindLeaving {
+				p.State = model.PlayerStateKindPlaying
+				return p, true
 			}
 
 			return nil, false
@@ -891,12 +1045,70 @@ func (r *Session) RemovePlayer(userID int64) {
 	}
 }
 
-// set PlayerStateKindLeaving status
+// RemoveGuest the guest plays from the owner's phone, so nobody else can remove it
+func (r *Session) RemoveGuest(ownerID, guestID int64) error {
+	player, ok := r.findPlayer(guestID)
+	if !ok || !player.IsGuest() || !player.IsPlaying() {
+		return ErrPlayerNotFound
+	}
+
+	if player.OwnerID != ownerID {
+		return ErrNotGuestOwner
+	}
+
+	r.RemovePlayer(guestID)
+
+	return nil
+}
+
+// FindGuest the playing guest of the owner by the name
+func (r *Session) FindGuest(ownerID int64, name string) (model.Player, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	for _, player := range r.Players {
+		if player.IsGuest() && player.OwnerID == ownerID && player.IsPlaying() && strings.EqualFold(player.User.FirstName, name) {
+			return *player, true
+		}
+	}
+
+	return model.Player{}, false
+}
+
+// Guests the playing guests of the owner
+func (r *Session) Guests(ownerID int64) []model.Player {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	var guests []model.Player
+	for _, player := range r.Players {
+		if player.IsGuest() && player.OwnerID == ownerID && player.IsPlaying() {
+			guests = append(guests, *player)
+		}
+	}
+
+	return guests
+}
+
+// offline players are controlled by the owner's phone, so an inactive offline player only loses the turn
+func (r *Session) dropInactive(player *model.Player) {
+	if !player.Offline {
+		r.RemovePlayer(player.UserID)
+		return
+	}
+
+	r.mtx.Lock()
+	player.Rates = append(player.Rates, &model.Rate{})
+	r.mtx.Unlock()
+	r.asyncBroadcast(fmt.Sprintf(resource.TextSkipTurnMsg, player.FormatFirstName()))
+}
+
+// set PlayerStateKindLeaving status, the guests leave together with their owner
 func (r *Session) removePlayer(userID int64) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 	for _, p := range r.Players {
-		if p.UserID == userID {
+		if p.UserID == userID || p.OwnerID == userID {
 			p.State = model.PlayerStateKindLeaving
 		}
 	}
@@ -904,22 +1116,98 @@ func (r *Session) removePlayer(userID int64) {
 
 // change vote condition and publish changes
 
-func (r *Session) thumbUp() {
+func (r *Session) castVote(v *vote, userID int64, up bool) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return v.cast(userID, up)
+}
+
+// typed modes: only the active player's messages are counted while the timer is running
+
+func (r *Session) startTyping(userID int64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.typingUserID = userID
+	r.typedWords = map[string]struct{}{}
+}
+
+func (r *Session) stopTyping() int {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
-	r.activeVote.thumbUp++
-	r.activeVote.pub <- struct{}{}
+	// a word per category at most, the repeated words are already counted once
+	words := len(r.typedWords)
+	if n := len(r.Config.Categories); n > 0 && words > n {
+		words = n
+	}
+
+	r.typingUserID = 0
+	r.typedWords = nil
+
+	return words
 }
 
-func (r *Session) thumbDown() {
+func (r *Session) isTyping(userID int64) bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.typingUserID != 0 && r.typingUserID == userID
+}
+
+func (r *Session) typeWords(text string) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
-	r.activeVote.thumbDown++
-	r.activeVote.pub <- struct{}{}
+	if r.typedWords == nil {
+		r.typedWords = map[string]struct{}{}
+	}
+
+	for _, word := range splitWords(text) {
+		r.typedWords[word] = struct{}{}
+	}
+}
+
+// the bloops drops on the dice, the turn gets its bonus points and seconds
+func (r *Session) rollBloops() (resource.Bloops, bool) {
+	if !r.dice() {
+		return resource.Bloops{}, false
+	}
+
+	bloops, ok := r.randBloopses()
+	if !ok {
+		return resource.Bloops{}, false
+	}
+
+	r.bloopsPoints = bloops.Points
+	r.currRoundSeconds = r.Config.RoundTime + bloops.Seconds
+
+	return bloops, true
+}
+
+func (r *Session) scoreTurn(rate *model.Rate, result RoundResult) {
+	rate.Points = r.scorer.Score(result)
+	rate.Completed = result.Completed()
+	rate.ScoredPoints = rate.Points
+	rate.ScoredCompleted = rate.Completed
+}
+
+func (r *Session) appendRate(player *model.Player, rate *model.Rate) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	player.Rates = append(player.Rates, rate)
+	r.turn = turnView{}
+
+	//  remove the bloops that played
+	if rate.Points > 0 && rate.BloopsName != "" {
+		for idx, bloops := range r.Config.Bloopses {
+			if bloops.Name == rate.BloopsName {
+				r.Config.Bloopses = append(r.Config.Bloopses[:idx], r.Config.Bloopses[idx+1:]...)
+				break
+			}
+		}
+	}
 }
 
 func (r *Session) dice() bool {
-	return (int)(fastrand.Uint32n(10)+1) < 7
+	return r.rnd.Intn(10)+1 < 7
 }
 
 func (r *Session) randBloopses() (resource.Bloops, bool) {
@@ -927,9 +1215,8 @@ func (r *Session) randBloopses() (resource.Bloops, bool) {
 		return resource.Bloops{}, false
 	}
 
-	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < 3; i++ {
-		rand.Shuffle(len(r.Config.Bloopses), func(i, j int) {
+		r.rnd.Shuffle(len(r.Config.Bloopses), func(i, j int) {
 			r.Config.Bloopses[i], r.Config.Bloopses[j] = r.Config.Bloopses[j], r.Config.Bloopses[i]
 		})
 	}
@@ -941,10 +1228,10 @@ func (r *Session) randBloopses() (resource.Bloops, bool) {
 func (r *Session) randWeightedBloopses() resource.Bloops {
 	var max float64 = -1
 	var result resource.Bloops
-	var mn, mx uint32
+	var mn, mx int
 
 	for mn == mx {
-		p1, p2 := fastrand.Uint32n(uint32(len(r.Config.Bloopses))), fastrand.Uint32n(uint32(len(r.Config.Bloopses)))
+		p1, p2 := r.rnd.Intn(len(r.Config.Bloopses)), r.rnd.Intn(len(r.Config.Bloopses))
 		if p1 > p2 {
 			mx, mn = p1, p2
 		} else {
@@ -953,7 +1240,7 @@ func (r *Session) randWeightedBloopses() resource.Bloops {
 	}
 
 	for _, challenge := range r.Config.Bloopses[mn:mx] {
-		rndNum := float64(fastrand.Uint32n(bloopsMaxWeight)) / bloopsMaxWeight
+		rndNum := float64(r.rnd.Intn(bloopsMaxWeight)) / bloopsMaxWeight
 		rnd := math.Pow(rndNum, 1/float64(challenge.Weight))
 		if rnd > max {
 			max = rnd
@@ -0,0 +1,49 @@
+package match
+
+import (
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+)
+
+// Turn what happened during the simulated turn
+type Turn struct {
+	Player *model.Player
+	Rate   *model.Rate
+	// the dropped bloops, nil for the usual turn
+	Bloops *resource.Bloops
+	Result RoundResult
+}
+
+// PlayFn plays the turn instead of the player, roundTime includes the bonus seconds of the bloops
+type PlayFn func(player *model.Player, bloops *resource.Bloops, roundTime int) RoundResult
+
+// SimulateTurn plays the next turn of the current round headless, without telegram and timers.
+// The player, the bloops and the points are chosen by the same rules as in the real match
+func (r *Session) SimulateTurn(play PlayFn) (Turn, bool) {
+	player, ok := r.nextPlayer()
+	if !ok {
+		return Turn{}, false
+	}
+
+	turn := Turn{Player: player, Rate: &model.Rate{}}
+
+	r.currRoundSeconds = r.Config.RoundTime
+	r.bloopsPoints = 0
+	if r.Config.IsBloops() {
+		if bloops, ok := r.rollBloops(); ok {
+			turn.Bloops = &bloops
+			turn.Rate.Bloops = true
+			turn.Rate.BloopsName = bloops.Name
+		}
+	}
+
+	turn.Result = play(player, turn.Bloops, r.currRoundSeconds)
+	turn.Result.RoundTime = r.currRoundSeconds
+	turn.Result.BloopsPoints = r.bloopsPoints
+	turn.Rate.Words = turn.Result.Words
+
+	r.scoreTurn(turn.Rate, turn.Result)
+	r.appendRate(player, turn.Rate)
+
+	return turn, true
+}
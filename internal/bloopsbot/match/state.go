@@ -0,0 +1,57 @@
+package match
+
+import (
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+)
+
+// Serialize the snapshot of the match to restore it after the restart of the bot
+func (r *Session) Serialize() model.State {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	s := model.State{
+		Timeout:      r.Config.Timeout,
+		AuthorID:     r.Config.AuthorID,
+		AuthorName:   r.Config.AuthorName,
+		RoundsNum:    r.Config.RoundsNum,
+		RoundTime:    r.Config.RoundTime,
+		Vote:         r.Config.Vote,
+		VoteMode:     uint8(r.Config.VoteMode),
+		VoteTime:     r.Config.VoteTime,
+		Scoring:      uint8(r.Config.Scoring),
+		HotSeat:      r.Config.HotSeat,
+		LiveBoard:    r.Config.LiveBoard,
+		TieBreakers:  SerializeTieBreakers(r.Config.TieBreakers),
+		SuddenDeath:  r.Config.SuddenDeath,
+		ViewToken:    r.Config.ViewToken,
+		Seed:         r.Config.Seed,
+		Code:         r.Config.Code,
+		State:        r.State,
+		CurrRoundIdx: r.CurrRoundIdx,
+		CreatedAt:    r.CreatedAt,
+		Categories:   make([]string, len(r.Config.Categories)),
+		Letters:      make([]string, len(r.Config.Letters)),
+		Bloopses:     make([]resource.Bloops, len(r.Config.Bloopses)),
+		Players:      make([]*model.Player, len(r.Players)),
+	}
+
+	copy(s.Categories, r.Config.Categories)
+	copy(s.Letters, r.Config.Letters)
+	copy(s.Bloopses, r.Config.Bloopses)
+
+	// the players keep playing while the snapshot is written
+	for i, player := range r.Players {
+		p := *player
+		p.Rates = make([]*model.Rate, len(player.Rates))
+		copy(p.Rates, player.Rates)
+		s.Players[i] = &p
+	}
+
+	return s
+}
+
+// Done is closed when the session has stopped and its results or snapshot are handed over
+func (r *Session) Done() <-chan struct{} {
+	return r.done
+}
@@ -0,0 +1,169 @@
+package match
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const viewTokenLen = 16
+
+// View snapshot of the match for the presenter screen
+type View struct {
+	Code      int64  `json:"code"`
+	State     string `json:"state"`
+	Round     int    `json:"round"`
+	RoundsNum int    `json:"roundsNum"`
+	// the extra round of the tied leaders
+	SuddenDeath bool        `json:"suddenDeath"`
+	Player      string      `json:"player"`
+	Letter      string      `json:"letter"`
+	Categories  []string    `json:"categories"`
+	Seconds     int         `json:"seconds"`
+	Vote        *ViewVote   `json:"vote,omitempty"`
+	Scores      []ViewScore `json:"scores"`
+}
+
+type ViewVote struct {
+	Up     int  `json:"up"`
+	Down   int  `json:"down"`
+	Hidden bool `json:"hidden"`
+}
+
+type ViewScore struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+	Rounds int    `json:"rounds"`
+	Place  int    `json:"place"`
+}
+
+// the part of the round state that exists only while the player's turn is in progress
+type turnView struct {
+	player  string
+	letter  string
+	seconds int
+}
+
+func newViewToken() string {
+	b := make([]byte, viewTokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
+
+func stateTitle(state uint8) string {
+	switch state {
+	case StateKindPlaying:
+		return "playing"
+	case StateKindProcessing:
+		return "processing"
+	case StateKindFinished:
+		return "finished"
+	default:
+		return "waiting"
+	}
+}
+
+// View the current state of the match
+func (r *Session) View() View {
+	scores := r.Scores()
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	view := View{
+		Code:        r.Config.Code,
+		State:       stateTitle(r.State),
+		Round:       r.CurrRoundIdx + 1,
+		RoundsNum:   r.Config.RoundsNum,
+		SuddenDeath: r.isSuddenDeath(),
+		Player:      r.turn.player,
+		Letter:      r.turn.letter,
+		Categories:  r.Config.Categories,
+		Seconds:     r.turn.seconds,
+		Scores:      make([]ViewScore, len(scores)),
+	}
+
+	for i, score := range scores {
+		view.Scores[i] = ViewScore{
+			Name:   score.Player.FormatFirstName(),
+			Points: score.Points,
+			Rounds: score.Rounds,
+			Place:  score.Place,
+		}
+	}
+
+	if r.activeVote != nil && !r.activeVote.closed {
+		view.Vote = &ViewVote{Hidden: !r.activeVote.mode.isPublic()}
+		if !view.Vote.Hidden {
+			view.Vote.Up, view.Vote.Down = r.activeVote.thumbUp, r.activeVote.thumbDown
+		}
+	}
+
+	return view
+}
+
+// Subscribe the channel receives a signal on every change of the view and is closed when the match is over
+func (r *Session) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	r.viewMtx.Lock()
+	defer r.viewMtx.Unlock()
+	if r.viewClosed {
+		close(ch)
+		return ch, func() {}
+	}
+	r.viewSubs[ch] = struct{}{}
+
+	return ch, func() {
+		r.viewMtx.Lock()
+		defer r.viewMtx.Unlock()
+		if _, ok := r.viewSubs[ch]; ok {
+			delete(r.viewSubs, ch)
+			close(ch)
+		}
+	}
+}
+
+func (r *Session) publishView() {
+	r.viewMtx.Lock()
+	defer r.viewMtx.Unlock()
+	for ch := range r.viewSubs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (r *Session) closeView() {
+	r.viewMtx.Lock()
+	defer r.viewMtx.Unlock()
+	r.viewClosed = true
+	for ch := range r.viewSubs {
+		delete(r.viewSubs, ch)
+		close(ch)
+	}
+}
+
+func (r *Session) setTurn(turn turnView) {
+	r.mtx.Lock()
+	r.turn = turn
+	r.mtx.Unlock()
+	r.publishView()
+}
+
+func (r *Session) setTurnLetter(letter string) {
+	r.mtx.Lock()
+	r.turn.letter = letter
+	r.mtx.Unlock()
+	r.publishView()
+}
+
+func (r *Session) setTurnSeconds(secs int) {
+	r.mtx.Lock()
+	r.turn.seconds = secs
+	r.mtx.Unlock()
+	r.publishView()
+}
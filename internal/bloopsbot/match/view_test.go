@@ -0,0 +1,37 @@
+package match
+
+import "testing"
+
+func TestSessionView(t *testing.T) {
+	t.Parallel()
+
+	s := NewSession(Config{Code: 1234, RoundsNum: 3, Categories: []string{"Города"}})
+	if s.Config.ViewToken == "" {
+		t.Fatalf("expected generated view token")
+	}
+
+	updates, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	s.setTurn(turnView{player: "Маша"})
+	s.setTurnLetter("А")
+	if _, ok := <-updates; !ok {
+		t.Fatalf("expected view update")
+	}
+
+	view := s.View()
+	if view.Player != "Маша" || view.Letter != "А" || view.Round != 1 || view.State != "waiting" {
+		t.Errorf("unexpected view %+v", view)
+	}
+
+	s.closeView()
+	if _, ok := <-updates; ok {
+		t.Errorf("expected closed updates channel")
+	}
+
+	// late subscribers of the finished match get a closed channel
+	late, _ := s.Subscribe()
+	if _, ok := <-late; ok {
+		t.Errorf("expected closed channel after the match is over")
+	}
+}
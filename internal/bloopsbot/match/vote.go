@@ -0,0 +1,137 @@
+package match
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+)
+
+type VoteMode uint8
+
+// zero value is treated as VoteModeAll, the rule used before vote modes existed
+const (
+	// everyone including the active player votes, ties go to the player
+	VoteModeAll VoteMode = iota + 1
+	// everyone except the active player votes, ties go to the player
+	VoteModeMajority
+	// only the author of the game decides
+	VoteModeHost
+	// two thirds of the votes are needed to reject the answer
+	VoteModeSupermajority
+	// simple majority, the tally is hidden until the end of the vote
+	VoteModeAnonymous
+)
+
+var VoteModes = []VoteMode{
+	VoteModeAll,
+	VoteModeMajority,
+	VoteModeHost,
+	VoteModeSupermajority,
+	VoteModeAnonymous,
+}
+
+var (
+	ErrVoteNotAllowed = fmt.Errorf("user is not allowed to vote")
+	ErrVoteTwice      = fmt.Errorf("user has already voted")
+	ErrVoteClosed     = fmt.Errorf("vote closed")
+)
+
+func (m VoteMode) Title() string {
+	switch m {
+	case VoteModeMajority:
+		return resource.TextVoteModeMajority
+	case VoteModeHost:
+		return resource.TextVoteModeHost
+	case VoteModeSupermajority:
+		return resource.TextVoteModeSupermajority
+	case VoteModeAnonymous:
+		return resource.TextVoteModeAnonymous
+	default:
+		return resource.TextVoteModeAll
+	}
+}
+
+// the tally is shown on the buttons while voting
+func (m VoteMode) isPublic() bool {
+	return m != VoteModeAnonymous
+}
+
+func newVote(mode VoteMode, voters []int64, timeout time.Duration) *vote {
+	v := &vote{
+		mode:    mode,
+		timeout: timeout,
+		voters:  make(map[int64]struct{}, len(voters)),
+		votes:   map[int64]bool{},
+		pub:     make(chan struct{}, 1),
+	}
+
+	for _, userID := range voters {
+		v.voters[userID] = struct{}{}
+	}
+
+	return v
+}
+
+type vote struct {
+	mode    VoteMode
+	timeout time.Duration
+
+	// key: UserID of the users allowed to vote
+	voters map[int64]struct{}
+	// key: UserID, value: thumb up
+	votes map[int64]bool
+
+	thumbUp   int
+	thumbDown int
+	closed    bool
+	pub       chan struct{}
+}
+
+// each voter has exactly one vote, repeated clicks are rejected
+func (v *vote) cast(userID int64, up bool) error {
+	if v.closed {
+		return ErrVoteClosed
+	}
+
+	if _, ok := v.voters[userID]; !ok {
+		return ErrVoteNotAllowed
+	}
+
+	if _, ok := v.votes[userID]; ok {
+		return ErrVoteTwice
+	}
+
+	v.votes[userID] = up
+	if up {
+		v.thumbUp++
+	} else {
+		v.thumbDown++
+	}
+
+	// non-blocking, the vote loop only needs to know that something has changed
+	select {
+	case v.pub <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (v *vote) close() {
+	v.closed = true
+}
+
+func (v *vote) didEveryoneVote() bool {
+	return len(v.votes) == len(v.voters)
+}
+
+// the verdict on the answer, if nobody voted the answer is accepted
+func (v *vote) accepted() bool {
+	switch v.mode {
+	case VoteModeSupermajority:
+		return v.thumbDown*3 < (v.thumbUp+v.thumbDown)*2 || v.thumbDown == 0
+	default:
+		return v.thumbUp >= v.thumbDown
+	}
+}
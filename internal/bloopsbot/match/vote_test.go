@@ -0,0 +1,62 @@
+package match
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVoteCast(t *testing.T) {
+	t.Parallel()
+
+	v := newVote(VoteModeMajority, []int64{1, 2}, time.Second)
+	if err := v.cast(1, false); err != nil {
+		t.Fatalf("cast: %v", err)
+	}
+
+	if err := v.cast(1, false); !errors.Is(err, ErrVoteTwice) {
+		t.Errorf("expected %v, got %v", ErrVoteTwice, err)
+	}
+
+	if err := v.cast(3, false); !errors.Is(err, ErrVoteNotAllowed) {
+		t.Errorf("expected %v, got %v", ErrVoteNotAllowed, err)
+	}
+
+	if v.didEveryoneVote() {
+		t.Errorf("expected vote to wait for the second voter")
+	}
+
+	v.close()
+	if err := v.cast(2, true); !errors.Is(err, ErrVoteClosed) {
+		t.Errorf("expected %v, got %v", ErrVoteClosed, err)
+	}
+}
+
+func TestVoteAccepted(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		mode     VoteMode
+		up, down int
+		accepted bool
+	}{
+		{name: "nobody_voted", mode: VoteModeMajority, accepted: true},
+		{name: "tie_goes_to_player", mode: VoteModeAll, up: 1, down: 1, accepted: true},
+		{name: "majority_rejects", mode: VoteModeMajority, up: 1, down: 2, accepted: false},
+		{name: "supermajority_not_reached", mode: VoteModeSupermajority, up: 2, down: 3, accepted: true},
+		{name: "supermajority_rejects", mode: VoteModeSupermajority, up: 1, down: 2, accepted: false},
+		{name: "host_rejects", mode: VoteModeHost, down: 1, accepted: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v := &vote{mode: tc.mode, thumbUp: tc.up, thumbDown: tc.down}
+			if got := v.accepted(); got != tc.accepted {
+				t.Errorf("expected %t, got %t", tc.accepted, got)
+			}
+		})
+	}
+}
@@ -1,12 +1,163 @@
 package bloopsbot
 
 import (
+	"context"
 	"fmt"
+	"runtime/debug"
+	"time"
 
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	moderationModel "github.com/bloops-games/bloops/internal/database/moderation/model"
 	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/logging"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
+// request the update passing through the middleware chain, the user is known after userMiddleware
+type request struct {
+	update tgbotapi.Update
+	user   userModel.User
+	chatID int64
+}
+
+func newRequest(upd tgbotapi.Update) *request {
+	req := &request{update: upd}
+	switch {
+	case upd.Message != nil && upd.Message.Chat != nil:
+		req.chatID = upd.Message.Chat.ID
+	case upd.CallbackQuery != nil && upd.CallbackQuery.Message != nil:
+		req.chatID = upd.CallbackQuery.Message.Chat.ID
+	}
+
+	return req
+}
+
+func (r *request) kind() string {
+	if r.update.CallbackQuery != nil {
+		return "callback"
+	}
+
+	return "message"
+}
+
+type (
+	requestHandlerFunc    = func(ctx context.Context, req *request) error
+	requestMiddlewareFunc = func(next requestHandlerFunc) requestHandlerFunc
+)
+
+// chainMiddleware the first middleware is the outermost one
+func chainMiddleware(h requestHandlerFunc, middleware ...requestMiddlewareFunc) requestHandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
+
+// recoverMiddleware a panic in a handler fails the update, not the dispatcher worker
+func recoverMiddleware(next requestHandlerFunc) requestHandlerFunc {
+	return func(ctx context.Context, req *request) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.FromContext(ctx).Named("bloopsbot.recoverMiddleware").Errorw(
+					"Panic while handling the update",
+					"panic", r,
+					"stack", string(debug.Stack()),
+				)
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+
+		return next(ctx, req)
+	}
+}
+
+// logMiddleware every log line of the update carries its fields
+func logMiddleware(next requestHandlerFunc) requestHandlerFunc {
+	return func(ctx context.Context, req *request) error {
+		logger := logging.FromContext(ctx).With(
+			"update_id", req.update.UpdateID,
+			"user_id", updateKey(req.update),
+			"chat_id", req.chatID,
+			"kind", req.kind(),
+		)
+		ctx = logging.WithLogger(ctx, logger)
+
+		start := time.Now()
+		err := next(ctx, req)
+		logger.Named("bloopsbot.logMiddleware").Debugw("Update handled", "duration", time.Since(start))
+
+		return err
+	}
+}
+
+// floodMiddleware drops the updates of the users sending faster than the limit, the user is warned once
+func (m *manager) floodMiddleware(next requestHandlerFunc) requestHandlerFunc {
+	return func(ctx context.Context, req *request) error {
+		allowed, warn := m.flood.allow(updateKey(req.update))
+		if allowed {
+			return next(ctx, req)
+		}
+
+		if query := req.update.CallbackQuery; query != nil {
+			if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, resource.TextFloodMsg)); err != nil {
+				return fmt.Errorf("send answer msg: %w", err)
+			}
+
+			return nil
+		}
+
+		if warn && req.chatID != 0 {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(req.chatID, resource.TextFloodMsg)); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func (m *manager) userMiddleware(next requestHandlerFunc) requestHandlerFunc {
+	return func(ctx context.Context, req *request) error {
+		u, err := m.recvUser(req.update)
+		if err != nil {
+			return fmt.Errorf("recv user: %w", err)
+		}
+
+		req.user = u
+
+		return next(ctx, req)
+	}
+}
+
+// banMiddleware banned users can not do anything, the clicks are answered with the ban term
+func (m *manager) banMiddleware(next requestHandlerFunc) requestHandlerFunc {
+	return func(ctx context.Context, req *request) error {
+		text, banned, err := m.banStatus(req.user)
+		if err != nil {
+			return fmt.Errorf("ban status: %w", err)
+		}
+
+		if !banned {
+			return next(ctx, req)
+		}
+
+		if query := req.update.CallbackQuery; query != nil {
+			if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, text)); err != nil {
+				return fmt.Errorf("send answer msg: %w", err)
+			}
+
+			return nil
+		}
+
+		if _, err := m.tg.Send(tgbotapi.NewMessage(req.chatID, text)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+}
+
 func (m *manager) isAdmin(u userModel.User, chatID int64) (bool, error) {
 	if !u.Admin {
 		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, "Для этой команды нужны права администратора")); err != nil {
@@ -19,14 +170,24 @@ func (m *manager) isAdmin(u userModel.User, chatID int64) (bool, error) {
 	return true, nil
 }
 
-func (m *manager) isActive(u userModel.User, chatID int64) (bool, error) {
-	if !u.Admin && u.Status == userModel.StatusBanned {
-		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, "Бан")); err != nil {
-			return false, fmt.Errorf("send msg: %w", err)
+// banStatus the expired bans are lifted on the way, the text explains the active ban to the user
+func (m *manager) banStatus(u userModel.User) (string, bool, error) {
+	if u.Admin || u.Status != userModel.StatusBanned {
+		return "", false, nil
+	}
+
+	if !u.IsBanned(time.Now()) {
+		if err := m.unban(0, u, moderationModel.ActionExpire); err != nil {
+			return "", false, fmt.Errorf("unban: %w", err)
 		}
 
-		return false, nil
+		return "", false, nil
 	}
 
-	return true, nil
+	text := fmt.Sprintf("Бан %s", renderBanTerm(u))
+	if u.BanReason != "" {
+		text += fmt.Sprintf(". Причина: %s", u.BanReason)
+	}
+
+	return text, true, nil
 }
@@ -0,0 +1,398 @@
+package bloopsbot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	moderationDb "github.com/bloops-games/bloops/internal/database/moderation/database"
+	moderationModel "github.com/bloops-games/bloops/internal/database/moderation/model"
+	userDb "github.com/bloops-games/bloops/internal/database/user/database"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/strpool"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const banTimeLayout = "02.01.2006 15:04"
+
+var errBanTerm = fmt.Errorf("invalid ban term")
+
+// parseBanTerm accepts time.ParseDuration values and days like 7d, zero means a permanent ban
+func parseBanTerm(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "0" {
+		return 0, nil
+	}
+
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || days <= 0 {
+			return 0, errBanTerm
+		}
+
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, errBanTerm
+	}
+
+	return d, nil
+}
+
+func (m *manager) handleBanCommand(u userModel.User, chatID int64) error {
+	msg := tgbotapi.NewMessage(chatID, resource.TextBanMsg)
+	msg.ReplyMarkup = resource.CommonButtons
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(args string) error {
+		return m.banUser(u, chatID, args)
+	})
+
+	return nil
+}
+
+// banUser /ban @username 7d reason, the missing arguments are asked one by one
+func (m *manager) banUser(u userModel.User, chatID int64, args string) error {
+	username, args := splitArg(args)
+	username = strings.TrimPrefix(username, "@")
+	banned, err := m.userDB.FetchByUsername(username)
+	if err != nil {
+		if errors.Is(err, userDb.ErrNotFound) {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextBanUserNotFoundMsg, username))); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("fetch by username: %w", err)
+	}
+
+	if banned.Admin {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBanAdminMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	if args != "" {
+		return m.banUserTerm(u, chatID, banned, args)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBanTermMsg)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(args string) error {
+		return m.banUserTerm(u, chatID, banned, args)
+	})
+
+	return nil
+}
+
+func (m *manager) banUserTerm(u userModel.User, chatID int64, banned userModel.User, args string) error {
+	term, reason := splitArg(args)
+	d, err := parseBanTerm(term)
+	if err != nil {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBanTermErrMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		m.registerCommandCbHandler(u.ID, func(args string) error {
+			return m.banUserTerm(u, chatID, banned, args)
+		})
+
+		return nil
+	}
+
+	if reason != "" {
+		return m.banUserReason(u, chatID, banned, d, reason)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBanReasonMsg)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(reason string) error {
+		return m.banUserReason(u, chatID, banned, d, reason)
+	})
+
+	return nil
+}
+
+func (m *manager) banUserReason(u userModel.User, chatID int64, banned userModel.User, d time.Duration, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextBanReasonEmptyMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	m.deleteCommandCbHandler(u.ID)
+
+	if err := m.ban(u, banned, d, reason); err != nil {
+		return fmt.Errorf("ban: %w", err)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextBannedMsg, banned.Username))); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) handleUnbanCommand(u userModel.User, chatID int64) error {
+	msg := tgbotapi.NewMessage(chatID, resource.TextUnbanMsg)
+	msg.ReplyMarkup = resource.CommonButtons
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(username string) error {
+		return m.unbanUser(u, chatID, username)
+	})
+
+	return nil
+}
+
+// unbanUser /unban @username
+func (m *manager) unbanUser(u userModel.User, chatID int64, username string) error {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+	banned, err := m.userDB.FetchByUsername(username)
+	if err != nil {
+		if errors.Is(err, userDb.ErrNotFound) {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextBanUserNotFoundMsg, username))); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("fetch by username: %w", err)
+	}
+
+	m.deleteCommandCbHandler(u.ID)
+
+	if banned.Status != userModel.StatusBanned {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextUnbanNotBannedMsg, username))); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := m.unban(u.ID, banned, moderationModel.ActionUnban); err != nil {
+		return fmt.Errorf("unban: %w", err)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextUnbannedMsg, username))); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) handleBanListCommand(_ userModel.User, chatID int64) error {
+	users, err := m.userDB.FetchAll()
+	if err != nil && !errors.Is(err, userDb.ErrNotFound) {
+		return fmt.Errorf("fetch all users: %w", err)
+	}
+
+	now := time.Now()
+	var banned []userModel.User
+	for _, u := range users {
+		if u.IsBanned(now) {
+			banned = append(banned, u)
+		}
+	}
+
+	text := resource.TextBanListEmptyMsg
+	if len(banned) > 0 {
+		text = renderBanList(banned)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) handleBanLogCommand(u userModel.User, chatID int64) error {
+	msg := tgbotapi.NewMessage(chatID, resource.TextBanLogMsg)
+	msg.ReplyMarkup = resource.CommonButtons
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerCommandCbHandler(u.ID, func(username string) error {
+		return m.sendBanLog(u, chatID, username)
+	})
+
+	return nil
+}
+
+// sendBanLog /banlog @username, the actions of the moderation audit log of the user
+func (m *manager) sendBanLog(u userModel.User, chatID int64, username string) error {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+	target, err := m.userDB.FetchByUsername(username)
+	if err != nil {
+		if errors.Is(err, userDb.ErrNotFound) {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextBanUserNotFoundMsg, username))); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("fetch by username: %w", err)
+	}
+
+	m.deleteCommandCbHandler(u.ID)
+
+	records, err := m.moderationDB.FetchByUserID(target.ID)
+	if err != nil && !errors.Is(err, moderationDb.ErrNotFound) {
+		return fmt.Errorf("moderation db fetch: %w", err)
+	}
+
+	text := fmt.Sprintf(resource.TextBanLogEmptyMsg, username)
+	if len(records) > 0 {
+		text = m.renderBanLog(username, records)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) ban(admin, banned userModel.User, d time.Duration, reason string) error {
+	var until time.Time
+	if d > 0 {
+		until = time.Now().Add(d)
+	}
+
+	banned.Status = userModel.StatusBanned
+	banned.BannedUntil = until
+	banned.BanReason = reason
+	if err := m.userDB.Store(banned); err != nil {
+		return fmt.Errorf("user db store: %w", err)
+	}
+
+	if err := m.moderationDB.Append(moderationModel.NewRecord(
+		moderationModel.ActionBan,
+		admin.ID,
+		banned.ID,
+		banned.Username,
+		reason,
+		until,
+	)); err != nil {
+		return fmt.Errorf("moderation db append: %w", err)
+	}
+
+	return nil
+}
+
+// unban adminID is zero when the timed ban has expired
+func (m *manager) unban(adminID int64, banned userModel.User, action moderationModel.Action) error {
+	banned.Status = userModel.StatusActive
+	banned.BannedUntil = time.Time{}
+	banned.BanReason = ""
+	if err := m.userDB.Store(banned); err != nil {
+		return fmt.Errorf("user db store: %w", err)
+	}
+
+	if err := m.moderationDB.Append(moderationModel.NewRecord(
+		action,
+		adminID,
+		banned.ID,
+		banned.Username,
+		"",
+		time.Time{},
+	)); err != nil {
+		return fmt.Errorf("moderation db append: %w", err)
+	}
+
+	return nil
+}
+
+func renderBanList(users []userModel.User) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	_, _ = fmt.Fprintf(buf, "%s Забаненные пользователи\n\n", emoji.NoEntry.String())
+	for _, u := range users {
+		_, _ = fmt.Fprintf(buf, "@%s - %s, %s\n", u.Username, renderBanTerm(u), u.BanReason)
+	}
+
+	return buf.String()
+}
+
+// renderBanLog the admins are shown by the current username, the expired bans have no admin
+func (m *manager) renderBanLog(username string, records []moderationModel.Record) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	admins := map[int64]string{}
+	_, _ = fmt.Fprintf(buf, "%s История банов @%s\n\n", emoji.Scroll.String(), username)
+	for _, r := range records {
+		_, _ = fmt.Fprintf(buf, "%s ", r.CreatedAt.Format(banTimeLayout))
+		switch r.Action {
+		case moderationModel.ActionBan:
+			until := "навсегда"
+			if !r.Until.IsZero() {
+				until = "до " + r.Until.Format(banTimeLayout)
+			}
+
+			_, _ = fmt.Fprintf(buf, "бан %s, %s", until, r.Reason)
+		case moderationModel.ActionUnban:
+			buf.WriteString("разбан")
+		case moderationModel.ActionExpire:
+			buf.WriteString("бан истек")
+		}
+
+		if r.AdminID != 0 {
+			admin, ok := admins[r.AdminID]
+			if !ok {
+				admin = strconv.FormatInt(r.AdminID, 10)
+				if u, err := m.userDB.Fetch(r.AdminID); err == nil && u.Username != "" {
+					admin = "@" + u.Username
+				}
+
+				admins[r.AdminID] = admin
+			}
+
+			_, _ = fmt.Fprintf(buf, " (%s)", admin)
+		}
+
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+func renderBanTerm(u userModel.User) string {
+	if u.BannedUntil.IsZero() {
+		return "навсегда"
+	}
+
+	return "до " + u.BannedUntil.Format(banTimeLayout)
+}
@@ -0,0 +1,151 @@
+package bloopsbot
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed" // presenter page
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/bloops-games/bloops/internal/logging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	presenterPath      = "/tv/"
+	presenterHeartbeat = 15 * time.Second
+)
+
+//go:embed static/presenter.html
+var presenterPage []byte
+
+// HandlePresenter the presenter screen: /tv/<code>?token=<token> is the page, /tv/<code>/events is the SSE stream
+func (m *manager) HandlePresenter(ctx context.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, presenterPath)
+		events := strings.HasSuffix(path, "/events")
+		code, err := strconv.ParseInt(strings.TrimSuffix(path, "/events"), 10, 64)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		session, ok := m.matchSession(code)
+		if !ok || !validViewToken(session, r.URL.Query().Get("token")) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if !events {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write(presenterPage)
+			return
+		}
+
+		m.streamView(ctx, w, r, session)
+	})
+}
+
+func validViewToken(session *match.Session, token string) bool {
+	return session.Config.ViewToken != "" &&
+		subtle.ConstantTimeCompare([]byte(session.Config.ViewToken), []byte(token)) == 1
+}
+
+func (m *manager) streamView(ctx context.Context, w http.ResponseWriter, r *http.Request, session *match.Session) {
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.streamView")
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	updates, unsubscribe := session.Subscribe()
+	defer unsubscribe()
+
+	send := func() bool {
+		bytes, err := json.Marshal(session.View())
+		if err != nil {
+			logger.Errorf("marshal: %v", err)
+			return false
+		}
+
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", bytes); err != nil {
+			return false
+		}
+		flusher.Flush()
+
+		return true
+	}
+
+	if !send() {
+		return
+	}
+
+	heartbeat := time.NewTicker(presenterHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.Context().Done():
+			return
+		case _, ok := <-updates:
+			// the final state is sent once more when the match is over
+			if !send() || !ok {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (m *manager) presenterURL(session *match.Session) string {
+	base := m.config.PresenterURL
+	if base == "" {
+		base = "http://localhost:" + m.config.Port
+	}
+
+	return fmt.Sprintf(
+		"%s%s%d?token=%s",
+		strings.TrimSuffix(base, "/"),
+		presenterPath,
+		session.Config.Code,
+		url.QueryEscape(session.Config.ViewToken),
+	)
+}
+
+func (m *manager) handlePresenterCommand(u userModel.User, chatID int64) error {
+	session, ok := m.userMatchSession(u.ID)
+	if !ok || session.Config.AuthorID != u.ID {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextPresenterNotAuthorMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextPresenterMsg, m.presenterURL(session)))
+	msg.DisableWebPagePreview = true
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package bloopsbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/builder"
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	presetDb "github.com/bloops-games/bloops/internal/database/preset/database"
+	presetModel "github.com/bloops-games/bloops/internal/database/preset/model"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+	"github.com/enescakir/emoji"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	maxPresetNameLength = 20
+	// the name is a part of the inline data limited by 64 bytes, the emoji and the letters take several bytes
+	maxPresetDataLength = 64
+	maxPresetsNum       = 10
+
+	presetSaveData         = "preset:save"
+	presetPlayDataPrefix   = "preset:play:"
+	presetDeleteDataPrefix = "preset:delete:"
+)
+
+// offerPreset the author can save the settings of the created game under a name
+func (m *manager) offerPreset(session *builder.Session) error {
+	msg := tgbotapi.NewMessage(session.ChatID, resource.TextPresetOfferMsg)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(resource.TextPresetSave, presetSaveData),
+	))
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	// the builder session is finished, the settings are copied right away
+	preset := session.Preset("")
+	chatID := session.ChatID
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		m.deleteQueryCbHandler(chatID, output.MessageID)
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextPresetNameMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		m.registerCommandCbHandler(preset.UserID, func(name string) error {
+			return m.savePreset(chatID, preset, name)
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (m *manager) savePreset(chatID int64, preset presetModel.Preset, name string) error {
+	name = strings.TrimSpace(name)
+	if !validPresetName(name) {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextPresetNameErrMsg, maxPresetNameLength))); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	m.deleteCommandCbHandler(preset.UserID)
+
+	presets, err := m.presetDB.FetchByUserID(preset.UserID)
+	if err != nil && !errors.Is(err, presetDb.ErrNotFound) {
+		return fmt.Errorf("fetch presets: %w", err)
+	}
+
+	if len(presets) >= maxPresetsNum {
+		// the preset with the same name is replaced and does not count
+		replaced := false
+		for _, p := range presets {
+			if p.Name == name {
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextPresetLimitMsg, maxPresetsNum))); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+	}
+
+	preset.Name = name
+	preset.CreatedAt = time.Now()
+	if err := m.presetDB.Add(preset); err != nil {
+		return fmt.Errorf("preset db add: %w", err)
+	}
+
+	if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextPresetSavedMsg, name))); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	return nil
+}
+
+// validPresetName the longest inline data of the preset buttons must fit the limit of telegram
+func validPresetName(name string) bool {
+	return name != "" &&
+		utf8.RuneCountInString(name) <= maxPresetNameLength &&
+		len(presetDeleteDataPrefix)+len(name) <= maxPresetDataLength
+}
+
+func (m *manager) handlePresetsCommand(u userModel.User, chatID int64) error {
+	markup, ok, err := m.renderPresets(u.ID)
+	if err != nil {
+		return fmt.Errorf("render presets: %w", err)
+	}
+
+	if !ok {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextPresetsEmptyMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	msg := tgbotapi.NewMessage(chatID, resource.TextPresetsMsg)
+	msg.ReplyMarkup = markup
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(chatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		return m.handlePresetQuery(u, chatID, output.MessageID, query)
+	})
+
+	return nil
+}
+
+func (m *manager) handlePresetQuery(u userModel.User, chatID int64, messageID int, query *tgbotapi.CallbackQuery) error {
+	switch {
+	case strings.HasPrefix(query.Data, presetPlayDataPrefix):
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		return m.playPreset(u, chatID, strings.TrimPrefix(query.Data, presetPlayDataPrefix))
+	case strings.HasPrefix(query.Data, presetDeleteDataPrefix):
+		name := strings.TrimPrefix(query.Data, presetDeleteDataPrefix)
+		if err := m.presetDB.Delete(u.ID, name); err != nil && !errors.Is(err, presetDb.ErrNotFound) {
+			return fmt.Errorf("preset db delete: %w", err)
+		}
+
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, fmt.Sprintf(resource.TextPresetDeletedMsg, name))); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		markup, ok, err := m.renderPresets(u.ID)
+		if err != nil {
+			return fmt.Errorf("render presets: %w", err)
+		}
+
+		if !ok {
+			m.deleteQueryCbHandler(chatID, messageID)
+			if _, err := m.tg.Send(tgbotapi.NewEditMessageText(chatID, messageID, resource.TextPresetsEmptyMsg)); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		if _, err := m.tg.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, markup)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderPresets one row per preset: create the game, delete the preset
+func (m *manager) renderPresets(userID int64) (tgbotapi.InlineKeyboardMarkup, bool, error) {
+	markup := tgbotapi.NewInlineKeyboardMarkup()
+	presets, err := m.presetDB.FetchByUserID(userID)
+	if err != nil {
+		if errors.Is(err, presetDb.ErrNotFound) {
+			return markup, false, nil
+		}
+
+		return markup, false, fmt.Errorf("fetch presets: %w", err)
+	}
+
+	for _, p := range presets {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(emoji.PlayButton.String()+" "+p.Name, presetPlayDataPrefix+p.Name),
+			tgbotapi.NewInlineKeyboardButtonData(emoji.Wastebasket.String(), presetDeleteDataPrefix+p.Name),
+		))
+	}
+
+	return markup, len(presets) > 0, nil
+}
+
+// playPreset /presets name, the lobby is created without the builder
+func (m *manager) playPreset(u userModel.User, chatID int64, name string) error {
+	name = strings.TrimSpace(name)
+	if m.isUserBusy(u.ID) {
+		if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextPresetBusyMsg)); err != nil {
+			return fmt.Errorf("send msg: %w", err)
+		}
+
+		return nil
+	}
+
+	preset, err := m.presetDB.Fetch(u.ID, name)
+	if err != nil {
+		if errors.Is(err, presetDb.ErrNotFound) {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextPresetNotFoundMsg, name))); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("fetch preset: %w", err)
+	}
+
+	session, err := NewBuilderSessionFromPreset(preset, m.tg, chatID, u.Username)
+	if err != nil {
+		return fmt.Errorf("new builder session from preset: %w", err)
+	}
+
+	if err := m.createMatch(session); err != nil {
+		return fmt.Errorf("create match: %w", err)
+	}
+
+	return nil
+}
+
+// isUserBusy the user is creating or playing a game
+func (m *manager) isUserBusy(userID int64) bool {
+	if _, ok := m.userBuildingSession(userID); ok {
+		return true
+	}
+
+	_, ok := m.userMatchSession(userID)
+	return ok
+}
+
+// NewBuilderSessionFromPreset the finished settings, the session is not run
+func NewBuilderSessionFromPreset(
+	preset presetModel.Preset,
+	tg *tgbotapi.BotAPI,
+	chatID int64,
+	authorName string,
+) (*builder.Session, error) {
+	s, err := builder.NewSession(tg, chatID, preset.UserID, authorName, nil, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("new builder session: %w", err)
+	}
+
+	s.RoundsNum = preset.RoundsNum
+	s.RoundTime = preset.RoundTime
+	s.Vote = preset.Vote
+	s.VoteMode = match.VoteMode(preset.VoteMode)
+	s.VoteTime = preset.VoteTime
+	s.Bloops = preset.Bloops
+	s.Scoring = match.ScoringKind(preset.Scoring)
+	s.HotSeat = preset.HotSeat
+	s.LiveBoard = preset.LiveBoard
+	s.TieBreakers = match.ParseTieBreakers(preset.TieBreakers)
+	s.SuddenDeath = preset.SuddenDeath
+	s.Categories = make([]resource.Category, len(preset.Categories))
+	s.Letters = make([]resource.Letter, len(preset.Letters))
+	s.PlayerNames = make([]string, len(preset.PlayerNames))
+
+	copy(s.Categories, preset.Categories)
+	copy(s.Letters, preset.Letters)
+	copy(s.PlayerNames, preset.PlayerNames)
+
+	return s, nil
+}
@@ -0,0 +1,29 @@
+package bloopsbot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidPresetName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "", expected: false},
+		{name: "Пятница", expected: true},
+		{name: strings.Repeat("a", maxPresetNameLength), expected: true},
+		{name: strings.Repeat("a", maxPresetNameLength+1), expected: false},
+		// 20 runes, but 80 bytes of the inline data
+		{name: strings.Repeat("🔥", maxPresetNameLength), expected: false},
+		{name: strings.Repeat("игра", 5), expected: true},
+	}
+
+	for _, tc := range testCases {
+		if got := validPresetName(tc.name); got != tc.expected {
+			t.Errorf("%q: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
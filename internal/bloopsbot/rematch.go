@@ -0,0 +1,180 @@
+package bloopsbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	matchstateModel "github.com/bloops-games/bloops/internal/database/matchstate/model"
+	"github.com/bloops-games/bloops/internal/logging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const rematchData = "rematch"
+
+// offerRematch the author of the finished game can start it again with the same settings and players
+func (m *manager) offerRematch(session *match.Session) error {
+	chatID := session.Config.AuthorID
+	msg := tgbotapi.NewMessage(chatID, resource.TextRematchOfferMsg)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(resource.TextRematchButton, rematchData),
+	))
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	players := session.Serialize().Players
+	config := rematchConfig(session.Config, players)
+	m.registerQueryCbHandler(chatID, output.MessageID, func(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		if m.isUserBusy(config.AuthorID) {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(chatID, resource.TextPresetBusyMsg)); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		m.deleteQueryCbHandler(chatID, output.MessageID)
+		if err := m.rematch(ctx, chatID, config, players); err != nil {
+			return fmt.Errorf("rematch: %w", err)
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+// rematch the new lobby with the config of the finished game
+// the virtual players of the author are added right away, the others get the invitation
+func (m *manager) rematch(ctx context.Context, chatID int64, config match.Config, players []*matchstateModel.Player) error {
+	code, err := m.generateMatchCode()
+	if err != nil {
+		return fmt.Errorf("generate match code: %w", err)
+	}
+
+	config.Code = code
+	config.Seed = 0
+	config.State = 0
+	config.CurrRoundIdx = 0
+	config.ViewToken = ""
+
+	var names []string
+	for _, player := range players {
+		if player.Offline && player.ControllerID() == config.AuthorID {
+			names = append(names, player.User.FirstName)
+		}
+	}
+
+	matchSession := m.runMatch(config)
+	if config.HotSeat {
+		if err := m.startHotSeat(chatID, config.AuthorID, names, matchSession); err != nil {
+			return fmt.Errorf("start hot seat: %w", err)
+		}
+
+		return nil
+	}
+
+	author, err := m.userDB.Fetch(config.AuthorID)
+	if err != nil {
+		return fmt.Errorf("fetch user: %w", err)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(resource.TextRematchCreatedMsg, code))
+	msg.ParseMode = tgbotapi.ModeMarkdown
+	if _, err := m.tg.Send(msg); err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	if err := m.joinMatch(author, chatID, strconv.FormatInt(code, 10)); err != nil {
+		return fmt.Errorf("join match: %w", err)
+	}
+
+	for _, name := range names {
+		if err := m.addGuest(matchSession, chatID, author, name); err != nil {
+			return fmt.Errorf("add guest: %w", err)
+		}
+	}
+
+	// the invitation that has not been delivered does not stop the rematch
+	logger := logging.FromContext(ctx).Named("bloopsbot.manager.rematch")
+	invited := map[int64]struct{}{config.AuthorID: {}}
+	for _, player := range players {
+		if _, ok := invited[player.UserID]; ok || player.Offline {
+			continue
+		}
+
+		invited[player.UserID] = struct{}{}
+		if err := m.sendRematchInvite(player, author.FirstName, code); err != nil {
+			logger.Errorf("send rematch invite to %d: %v", player.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) sendRematchInvite(player *matchstateModel.Player, authorName string, code int64) error {
+	msg := tgbotapi.NewMessage(player.ChatID, fmt.Sprintf(resource.TextRematchInviteMsg, authorName))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(resource.TextRematchJoinButton, strconv.FormatInt(code, 10)),
+	))
+	output, err := m.tg.Send(msg)
+	if err != nil {
+		return fmt.Errorf("send msg: %w", err)
+	}
+
+	m.registerQueryCbHandler(player.ChatID, output.MessageID, func(_ context.Context, query *tgbotapi.CallbackQuery) error {
+		m.deleteQueryCbHandler(player.ChatID, output.MessageID)
+		if _, err := m.tg.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			return fmt.Errorf("send answer msg: %w", err)
+		}
+
+		u, err := m.userDB.Fetch(player.UserID)
+		if err != nil {
+			return fmt.Errorf("fetch user: %w", err)
+		}
+
+		if m.isUserBusy(u.ID) {
+			if _, err := m.tg.Send(tgbotapi.NewMessage(player.ChatID, resource.TextPresetBusyMsg)); err != nil {
+				return fmt.Errorf("send msg: %w", err)
+			}
+
+			return nil
+		}
+
+		return m.joinMatch(u, player.ChatID, query.Data)
+	})
+
+	return nil
+}
+
+// rematchConfig the finished match has used up and shuffled its bloopses, so the rematch gets the full pool
+// and its own copies of the settings
+func rematchConfig(config match.Config, players []*matchstateModel.Player) match.Config {
+	bloops := len(config.Bloopses) > 0
+	for _, player := range players {
+		for _, rate := range player.Rates {
+			if rate.Bloops {
+				bloops = true
+			}
+		}
+	}
+
+	config.Categories = append([]string{}, config.Categories...)
+	config.Letters = append([]string{}, config.Letters...)
+	config.TieBreakers = append([]match.TieBreaker{}, config.TieBreakers...)
+	config.Bloopses = []resource.Bloops{}
+	if bloops {
+		config.Bloopses = make([]resource.Bloops, len(resource.Bloopses))
+		copy(config.Bloopses, resource.Bloopses)
+	}
+
+	return config
+}
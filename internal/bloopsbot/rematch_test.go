@@ -0,0 +1,31 @@
+package bloopsbot
+
+import (
+	"testing"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	matchstateModel "github.com/bloops-games/bloops/internal/database/matchstate/model"
+)
+
+func TestRematchConfig(t *testing.T) {
+	t.Parallel()
+
+	finished := match.Config{Categories: []string{"Города"}, Letters: []string{"А"}, Bloopses: []resource.Bloops{}}
+	player := &matchstateModel.Player{Rates: []*matchstateModel.Rate{{Bloops: true, Points: 5}}}
+
+	config := rematchConfig(finished, []*matchstateModel.Player{player})
+	if len(config.Bloopses) != len(resource.Bloopses) {
+		t.Errorf("expected the full bloops pool, got %d bloopses", len(config.Bloopses))
+	}
+
+	config.Categories[0] = "Реки"
+	config.Letters[0] = "Б"
+	if finished.Categories[0] != "Города" || finished.Letters[0] != "А" {
+		t.Errorf("expected the rematch not to share the settings, got %v %v", finished.Categories, finished.Letters)
+	}
+
+	if config := rematchConfig(finished, nil); config.IsBloops() {
+		t.Errorf("expected no bloopses in the game without them")
+	}
+}
@@ -5,14 +5,26 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/bloops-games/bloops/internal/bloopsbot/achievement"
 	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	achievementModel "github.com/bloops-games/bloops/internal/database/achievement/model"
 	statModel "github.com/bloops-games/bloops/internal/database/stat/model"
 	userModel "github.com/bloops-games/bloops/internal/database/user/model"
 	"github.com/bloops-games/bloops/internal/strpool"
 	"github.com/enescakir/emoji"
 )
 
-func renderProfile(u userModel.User, stat statModel.AggregationStat) string {
+const (
+	profileHardLettersNum = 3
+	// one unlucky round does not make the letter hard
+	profileLetterMinRounds = 2
+)
+
+func renderProfile(
+	u userModel.User,
+	stat statModel.AggregationStat,
+	achievements []achievementModel.Achievement,
+) string {
 	buf := strpool.Get()
 	defer func() {
 		buf.Reset()
@@ -42,5 +54,45 @@ func renderProfile(u userModel.User, stat statModel.AggregationStat) string {
 	)
 	_, _ = fmt.Fprintf(buf, "%s Лучший счет раунда: %s", emoji.HundredPoints.String(), strconv.Itoa(stat.BestPoints))
 
+	if letters := stat.Letters.Hardest(profileHardLettersNum, profileLetterMinRounds); len(letters) > 0 {
+		_, _ = fmt.Fprintf(buf, "\n\n%s *Самые сложные буквы:*", emoji.Brain.String())
+		for _, letter := range letters {
+			p := stat.Letters[letter]
+			_, _ = fmt.Fprintf(
+				buf,
+				"\n%s - справился %d%%, в среднем %s",
+				letter,
+				int(p.CompletionRate()*100),
+				p.AvgDuration().Round(100*time.Millisecond).String(),
+			)
+		}
+	}
+	_, _ = fmt.Fprintf(
+		buf,
+		"\n\n%s *Достижения: %s/%s*\n",
+		emoji.Trophy.String(),
+		strconv.Itoa(len(achievements)),
+		strconv.Itoa(len(achievement.Definitions)),
+	)
+
+	for _, a := range achievements {
+		if definition, ok := achievement.Find(a.Kind); ok {
+			_, _ = fmt.Fprintf(buf, "%s %s\n", definition.Icon, definition.Title)
+		}
+	}
+
+	return buf.String()
+}
+
+func renderAchievementUnlocked(definition achievement.Definition) string {
+	buf := strpool.Get()
+	defer func() {
+		buf.Reset()
+		strpool.Put(buf)
+	}()
+
+	_, _ = fmt.Fprintf(buf, "%s *Новое достижение!*\n\n", emoji.Trophy.String())
+	_, _ = fmt.Fprintf(buf, "%s *%s*\n%s", definition.Icon, definition.Title, definition.Description)
+
 	return buf.String()
 }
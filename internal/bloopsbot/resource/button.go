@@ -18,6 +18,7 @@ var (
 	RuleButtonText        = "Правила"
 	GameSettingButtonText = "Параметры игы"
 	ProfileButtonText     = emoji.Alien.String() + " Профиль"
+	PresetsButtonText     = emoji.CardIndexDividers.String() + " Мои игры"
 
 	// builder inline button text
 	BuilderInlineNextText = "Далее"
@@ -37,12 +38,37 @@ var (
 	RatingButton      = tgbotapi.NewKeyboardButton(RatingButtonText)
 	RulesButton       = tgbotapi.NewKeyboardButton(RuleButtonText)
 	ProfileButton     = tgbotapi.NewKeyboardButton(ProfileButtonText)
+	PresetsButton     = tgbotapi.NewKeyboardButton(PresetsButtonText)
 	GameSettingButton = tgbotapi.NewKeyboardButton(GameSettingButtonText)
 
 	CommonButtons = tgbotapi.NewReplyKeyboard(
-		tgbotapi.NewKeyboardButtonRow(CreateButton),
+		tgbotapi.NewKeyboardButtonRow(CreateButton, PresetsButton),
 		tgbotapi.NewKeyboardButtonRow(JoinButton),
 		tgbotapi.NewKeyboardButtonRow(RulesButton, ProfileButton),
 	)
 	LeaveMenuButton = tgbotapi.NewKeyboardButton(LeaveButtonText)
 )
+
+// the texts of the reply keyboard buttons come as plain messages
+var keyboardButtonTexts = []string{
+	CreateButtonText,
+	LeaveButtonText,
+	StartButtonText,
+	JoinButtonText,
+	RatingButtonText,
+	RuleButtonText,
+	GameSettingButtonText,
+	ProfileButtonText,
+	PresetsButtonText,
+}
+
+// IsKeyboardButtonText the message is a tap on the reply keyboard, not a typed text
+func IsKeyboardButtonText(text string) bool {
+	for _, buttonText := range keyboardButtonTexts {
+		if text == buttonText {
+			return true
+		}
+	}
+
+	return false
+}
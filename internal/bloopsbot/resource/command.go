@@ -5,7 +5,20 @@ var (
 	CmdStart     = "/start"
 	CmdRules     = "/rules"
 	CmdAddPlayer = "/add"
+	CmdRemove    = "/remove"
+	CmdGuests    = "/guests"
+	CmdJoin      = "/join"
 	CmdProfile   = "/profile"
 	CmdFeedback  = "/feedback"
 	CmdBan       = "/ban"
+	CmdUnban     = "/unban"
+	CmdBanList   = "/banlist"
+	CmdBanLog    = "/banlog"
+	CmdBroadcast = "/broadcast"
+	CmdInbox     = "/inbox"
+	CmdPresenter = "/tv"
+	CmdPresets   = "/presets"
+	CmdCorrect   = "/fix"
+	CmdHistory   = "/history"
+	CmdVs        = "/vs"
 )
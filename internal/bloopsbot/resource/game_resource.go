@@ -66,6 +66,7 @@ var (
 
 	RoundsNum  = []int{1, 2, 3, 4, 5}
 	RoundTimes = []int{30, 45, 60}
+	VoteTimes  = []int{15, 30, 60}
 
 	Bloopses = []Bloops{
 		{Name: emoji.Cinema.String() + " Артхаус режиссер", Weight: 2, Seconds: +30, Points: +10, Task: "К тебе ворвался режиссер артхаус кино и предложил помочь со своим проектом, тебе нужно заменить категории в игре на категорию *кино и актеры*\nНазывай имена фильмов, актеров или режиссеров на выпавшую букву"},
@@ -7,15 +7,40 @@ var (
 	TextAuthorGreetingMsg = "\n\nТы - ведущий игрок " + emoji.FlexedBiceps.String() + "\n\n" +
 		"Когда все игроки присоединятся тебе нужно нажать\n" + emoji.Rocket.String() + " *Начать* " + " для старта"
 	TextJoinedGameMsg                      = "Ты присоединился к игре! "
-	TextFeedbackMsg                        = "Ты можешь отправить анонимный отзыв"
+	TextFeedbackMsg                        = "Напиши отзыв, администратор сможет тебе ответить"
+	TextPresenterMsg                       = emoji.Television.String() + " Открой ссылку в браузере на телевизоре или ноутбуке:\n\n%s"
+	TextPresenterNotAuthorMsg              = "Ссылка на экран для телевизора доступна только ведущему игры"
+	TextFeedbackThanksMsg                  = emoji.SmilingFaceWithHearts.String() + " Спасибо за отзыв!"
+	TextFeedbackNewMsg                     = "Прилетел новый отзыв, список отзывов: " + CmdInbox
+	TextInboxEmptyMsg                      = "Отзывов пока нет"
+	TextInboxReplyMsg                      = "Отправь ответ на отзыв"
+	TextInboxRepliedMsg                    = "Ответ отправлен"
+	TextInboxAnswerMsg                     = emoji.Envelope.String() + " Ответ на твой отзыв:\n\n%s"
+	TextInboxReply                         = emoji.LeftArrowCurvingRight.String() + " Ответить"
+	TextInboxBack                          = emoji.LeftArrow.String() + " Назад"
+	TextInboxPrev                          = emoji.LeftArrow.String()
+	TextInboxNext                          = emoji.RightArrow.String()
 	TextBanMsg                             = "Отправь username пользователя"
+	TextBanTermMsg                         = "Отправь срок бана: 30m, 12h, 7d или 0 - навсегда"
+	TextBanTermErrMsg                      = "Не получилось разобрать срок, например: 30m, 12h, 7d или 0"
+	TextBanReasonMsg                       = "Отправь причину бана"
+	TextBanReasonEmptyMsg                  = "Причина бана обязательна"
+	TextBanAdminMsg                        = "Нельзя забанить администратора"
+	TextBanUserNotFoundMsg                 = "Пользователь не найден: %s"
+	TextBannedMsg                          = "Пользователь забанен: %s"
+	TextUnbanMsg                           = "Отправь username пользователя, которого нужно разбанить"
+	TextUnbanNotBannedMsg                  = "Пользователь не забанен: %s"
+	TextUnbannedMsg                        = "Пользователь разбанен: %s"
+	TextBanListEmptyMsg                    = "Забаненных пользователей нет"
+	TextBanLogMsg                          = "Отправь username пользователя, чтобы посмотреть историю банов"
+	TextBanLogEmptyMsg                     = "У пользователя %s нет банов"
 	TextGameRoomNotFoundMsg                = "Игровая комната не найдена"
 	TextSendJoinedCodeMsg                  = "Отправь код подключения к игре"
 	TextLeavingSessionsMsg                 = "Ты покинул все игровые сеансы"
 	TextSendOfflinePlayerUsernameMsg       = "Отправь имя оффлайн пользователя"
 	TextSendProfileMsg                     = "Отправь @username пользователя"
-	TextBuilderWarnMsg                     = emoji.BrokenHeart.String() + " К сожалению " + emoji.Robot.String() + " бот обновляется, необходимо попробовать заново через несколько минут"
-	TextMatchWarnMsg                       = emoji.BrokenHeart.String() + " К сожалению " + emoji.Robot.String() + " бот обновляется, этот раунд начнется заново через несколько секунд!"
+	TextBuilderWarnMsg                     = emoji.BrokenHeart.String() + " К сожалению " + emoji.Robot.String() + " бот перезапускается, настройки игры сохранены, продолжим с того же места через пару минут"
+	TextMatchWarnMsg                       = emoji.BrokenHeart.String() + " К сожалению " + emoji.Robot.String() + " бот перезапускается, игра продолжится после перезапуска, этот ход начнется заново"
 	TextProfileCmdUserNotFound             = "Пользователь не найден"
 	TextGameRoomNotFound                   = "Тебе нужно присоединиться к игре, чтобы добавлять оффлайн игроков"
 	TextOfflinePlayerAdded                 = "Оффлайн игрок добавлен. Все сообщения будут приходить тебе"
@@ -48,22 +73,126 @@ var (
 		"*Список команд:* \n" +
 		"/start - устанавливает бот и отправляет краткую справку по проекту\n" +
 		"/rules - отправляет набор правил игры\n" +
-		"/feedback - отправить анонимный отзыв\n" +
-		"/profile - позволяет посмотреть профиль другого игрока\n" +
-		"/add - если ты зашел в игровую команту, то можешь добавить игроков у которых нет телеграмма, так называемых виртуальных игроков, их задания будут приходить тебе. Ты можешь дать им свой смартфон, когда подойдет их очередь играть\n\n" +
+		"/feedback - отправить отзыв администратору\n" +
+		"/join - присоединиться к игре по коду, например /join 1234\n" +
+		"/profile - позволяет посмотреть профиль другого игрока, например /profile @username\n" +
+		"/add - если ты зашел в игровую команту, то можешь добавить игроков у которых нет телеграмма, так называемых виртуальных игроков, их задания будут приходить тебе. Ты можешь дать им свой смартфон, когда подойдет их очередь играть. Имя можно указать сразу: /add Бабушка\n" +
+		"/remove - убрать своего виртуального игрока из игры, например /remove Бабушка\n" +
+		"/guests - сохраненные виртуальные игроки и их статистика\n" +
+		"/history - последние игры, подробности о каждой по кнопке\n" +
+		"/vs - счет встреч с другим игроком, например /vs @username\n" +
+		"/fix - ведущий может исправить очки игрока за сыгранный раунд с указанием причины или переголосовать, например /fix Петя 2 5 назвал реку, которой нет\n\n" +
 		"*Обратная связь:* @robotomize\n" +
 		"*Проект на github:* [bloops_bot](https://github.com/robotomize/bloopsbot)"
-	TextChatNotAllowed = emoji.WomanGesturingNo.String() + " Бот не работает с групповыми чатами =("
+	TextBroadcastMsg          = emoji.Loudspeaker.String() + " Отправь текст рассылки, можно использовать Markdown"
+	TextBroadcastPreviewMsg   = "Так сообщение увидят пользователи. Отправить?"
+	TextBroadcastMarkupErrMsg = "Не удалось отправить превью, проверь разметку сообщения"
+	TextBroadcastRunningMsg   = "Рассылка уже идет, дождись завершения или останови её"
+	TextBroadcastConfirm      = emoji.Rocket.String() + " Отправить"
+	TextBroadcastCancel       = emoji.CrossMark.String() + " Отмена"
+	TextBroadcastStop         = emoji.StopSign.String() + " Остановить"
+	TextBroadcastCancelledMsg = "Рассылка отменена"
+	TextFloodMsg              = emoji.Snail.String() + " Слишком быстро, подождите пару секунд"
+	TextChatNotAllowed        = emoji.WomanGesturingNo.String() + " Бот не работает с групповыми чатами =("
+)
+
+// presets text messages
+var (
+	TextPresetOfferMsg    = emoji.FloppyDisk.String() + " Сохранить настройки этой игры, чтобы в следующий раз создать ее в одно касание?"
+	TextPresetSave        = emoji.FloppyDisk.String() + " Сохранить"
+	TextPresetNameMsg     = "Отправь название для настроек, например: Семейный вечер"
+	TextPresetNameErrMsg  = "Название должно быть не длиннее %d символов, эмодзи занимают больше места"
+	TextPresetLimitMsg    = "Можно сохранить не больше %d настроек, удали лишние: /presets"
+	TextPresetSavedMsg    = "Настройки «%s» сохранены, создать игру с ними можно кнопкой " + emoji.CardIndexDividers.String() + " Мои игры"
+	TextPresetsMsg        = emoji.CardIndexDividers.String() + " Сохраненные настройки, нажми на название, чтобы создать игру"
+	TextPresetsEmptyMsg   = "Сохраненных настроек пока нет, их можно сохранить после создания игры"
+	TextPresetNotFoundMsg = "Настройки «%s» не найдены"
+	TextPresetDeletedMsg  = "Настройки «%s» удалены"
+	TextPresetBusyMsg     = "Сначала выйди из текущей игры"
+	TextRematchOfferMsg   = emoji.ChequeredFlag.String() + " Игра окончена. Сыграть еще раз с теми же настройками и игроками?"
+	TextRematchButton     = emoji.RepeatButton.String() + " Реванш"
+	TextRematchCreatedMsg = emoji.RepeatButton.String() + " Реванш! Код игры *%d*, игрокам отправлены приглашения"
+	TextRematchInviteMsg  = emoji.RepeatButton.String() + " %s зовет тебя на реванш"
+	TextRematchJoinButton = emoji.VideoGame.String() + " Присоединиться"
+)
+
+// guests text messages
+var (
+	TextGuestsSavedMsg     = "Или выбери из сохраненных:"
+	TextGuestRemoveMsg     = "Кого из виртуальных игроков убрать из игры?"
+	TextGuestRemovedMsg    = "Виртуальный игрок %s покинул игру"
+	TextGuestNotFoundMsg   = "Виртуальный игрок «%s» не найден в игре"
+	TextGuestNotOwnerMsg   = "Убрать виртуального игрока может только тот, кто его добавил"
+	TextGuestsNoPlayingMsg = "В игре нет твоих виртуальных игроков"
+	TextGuestsMsg          = emoji.BustsInSilhouette.String() + " Виртуальные игроки\n\n"
+	TextGuestsEmptyMsg     = "Сохраненных виртуальных игроков пока нет, добавить их в игру можно командой /add"
+	TextGuestStatMsg       = "%s %s: сыграно %d, побед %d, лучший счет %d\n"
+	TextGuestDeletedMsg    = "Виртуальный игрок %s удален"
+)
+
+// history text messages
+var (
+	TextHistoryMsg         = emoji.Scroll.String() + " Последние игры, страница %d из %d\n\n"
+	TextHistoryEmptyMsg    = "Сыгранных игр пока нет"
+	TextHistoryLine        = "%d. %s, игра %d\n%s · %d очков · блюпсов: %d\n"
+	TextHistoryPlayers     = "Игроки: %s\n"
+	TextHistoryPlace       = "%d место из %d"
+	TextHistoryWin         = "победа"
+	TextHistoryPart        = "участие"
+	TextHistoryDetailMsg   = emoji.Scroll.String() + " Игра %d, %s\n\n"
+	TextHistoryBack        = emoji.LeftArrow.String() + " К списку"
+	TextHistoryPrev        = emoji.LeftArrow.String()
+	TextHistoryNext        = emoji.RightArrow.String()
+	TextHistoryYou         = "Ты"
+	TextVsMsg              = "Отправь @username игрока, с которым хочешь сравнить результаты"
+	TextVsSelfMsg          = "Нужен другой игрок"
+	TextVsNoGamesMsg       = "Вы с %s еще не играли вместе"
+	TextVsHeader           = emoji.CrossedSwords.String() + " Ты против %s\n\n"
+	TextVsResult           = "Игр вместе: %d\nПобед: %d\nПоражений: %d\nНичьих: %d\nОчки: %d - %d"
+	TextHistoryNotFoundMsg = "Игра не найдена"
+)
+
+// corrections text messages
+var (
+	TextCorrectionMsg         = emoji.Pencil.String() + " Ведущий изменил очки игрока *%s* за раунд %d: %d → %d\nПричина: %s"
+	TextRevoteSubject         = emoji.RepeatButton.String() + " Ведущий открыл повторное голосование за ответ игрока *%s* в раунде %d, буква %s"
+	TextRevoteReason          = "повторное голосование"
+	TextCorrectChoosePlayer   = "Чьи очки исправить?"
+	TextCorrectChooseRound    = "Выбери раунд игрока %s"
+	TextCorrectChooseAction   = "Раунд %d игрока %s: буква %s, %d очков"
+	TextCorrectEditButton     = emoji.Pencil.String() + " Изменить очки"
+	TextCorrectRevoteButton   = emoji.RepeatButton.String() + " Переголосовать"
+	TextCorrectPointsMsg      = "Отправь новые очки и причину, например: 5 назвал реку, которой нет"
+	TextCorrectFormatMsg      = "Нужно число очков и причина, например: /fix Петя 2 5 назвал реку, которой нет"
+	TextCorrectRevoteMsg      = "Повторное голосование открыто"
+	TextCorrectNotHostMsg     = "Исправлять очки может только ведущий"
+	TextCorrectNoGameMsg      = "Исправлять очки можно, пока игра не закрыта"
+	TextCorrectNoRoundsMsg    = "Еще нет сыгранных раундов"
+	TextCorrectRoundMsg       = "Раунд %d игрока %s не найден"
+	TextCorrectPointsRangeMsg = "Очки должны быть от -%d до %d"
+	TextCorrectVoteOffMsg     = "В этой игре нет голосования"
+	TextCorrectVoteBusyMsg    = "Сейчас уже идет голосование, попробуй позже"
+	TextCorrectNoVotersMsg    = "Некому голосовать за этот ответ"
+	TextCorrectNoPointsMsg    = "Игрок не набрал очков в этом раунде, голосовать не за что"
 )
 
 // builder text messages
 var (
-	TextChooseCategories            = "Выбери категории или напиши свою"
-	TextChooseRoundsNum             = "Выбери количество раундов(по умолчанию 1)"
-	TextDeleteComplexLetters        = "Убери сложные буквы"
-	TextVoteAllowed                 = emoji.Loudspeaker.String() + " Добавить голосование?\n\nПодробнее: /rules"
+	TextChooseCategories     = "Выбери категории или напиши свою"
+	TextChooseRoundsNum      = "Выбери количество раундов(по умолчанию 1)"
+	TextDeleteComplexLetters = "Убери сложные буквы"
+	TextHardLettersHint      = emoji.LightBulb.String() + " Чаще всего игроки не справляются с буквами: %s"
+	TextVoteAllowed          = emoji.Loudspeaker.String() + " Добавить голосование?\n\n" +
+		"*Все игроки* - голосуют все, ничья в пользу игрока\n" +
+		"*Большинство* - голосуют все, кроме отвечающего\n" +
+		"*Ведущий* - решает только ведущий\n" +
+		"*2/3 голосов* - чтобы не засчитать ответ нужно 2/3 голосов\n" +
+		"*Анонимно* - голоса скрыты до конца голосования\n\n" +
+		emoji.Stopwatch.String() + " Выбери время на голосование\n\nПодробнее: /rules"
 	TextBloopsAllowed               = emoji.GemStone.String() + " Добавить блюпсы?\n\nПодробнее: /rules"
-	TextConfigurationDone           = "Завершить процесс создания игры?"
+	TextChooseTie                   = emoji.BalanceScale.String() + " Как определить победителя при равных очках?\n\nНажми на правила в порядке важности, *доп. раунд* играют лидеры, если ничья осталась после последнего раунда"
+	TextBoardAllowed                = emoji.Pushpin.String() + " Включить табло?\n\nУ каждого игрока будет одно закрепленное сообщение с текущим игроком, временем и очками вместо сообщений о каждом ходе"
+	TextBuilderSummaryMsg           = emoji.Clipboard.String() + " Проверь настройки игры, чтобы изменить настройку, нажми на нее"
 	TextAddLeastCategoryToComplete  = "Необходимо добавить больше категорий"
 	TextAddLeastOneLetterToComplete = "Добавьте хотя бы одну букву для завершения"
 	TextAddedCategory               = "Добавлена категория %s"
@@ -73,6 +202,52 @@ var (
 	TextDeletedLetter               = "Удалена буква %s"
 	TextVoteYes                     = emoji.ThumbsUp.String() + " Да"
 	TextVoteNo                      = emoji.ThumbsDown.String() + " Нет"
+	TextChooseScoring               = emoji.HundredPoints.String() + " Выбери, как считать очки\n\n" +
+		"*Секунды* - очки равны оставшемуся времени\n" +
+		"*Фиксированные* - одинаковые очки за каждый завершенный раунд\n" +
+		"*Скорость* - чем быстрее, тем больше очков\n" +
+		"*Слова* - слова пишутся сообщениями, очки за каждое слово"
+	TextVoteOff               = emoji.CrossMark.String() + " Без голосования"
+	TextVoteModeAll           = "Все игроки"
+	TextVoteModeMajority      = "Большинство"
+	TextVoteModeHost          = "Ведущий"
+	TextVoteModeSupermajority = "2/3 голосов"
+	TextVoteModeAnonymous     = "Анонимно"
+	TextVoteModeAnswer        = "Голосование - %s"
+	TextVoteTimeAnswer        = "Время на голосование - %d сек"
+	TextScoringAnswer         = "Подсчет очков - %s"
+	TextScoringSeconds        = emoji.Stopwatch.String() + " Секунды"
+	TextScoringFlat           = emoji.ChequeredFlag.String() + " Фиксированные"
+	TextScoringTiers          = emoji.Rocket.String() + " Скорость"
+	TextScoringWords          = emoji.Pen.String() + " Слова"
+	TextTieBreakerDuration    = emoji.Stopwatch.String() + " Меньше времени"
+	TextTieBreakerCompleted   = emoji.CheckMarkButton.String() + " Больше раундов"
+	TextTieBreakerBloops      = emoji.GemStone.String() + " Больше блюпсов"
+	TextSuddenDeath           = emoji.CrossedSwords.String() + " Доп. раунд"
+	TextTiePointsOnly         = "Только очки"
+	TextChooseMode            = emoji.VideoGame.String() + " Как будете играть?\n\n" +
+		"*Каждый со своего телефона* - игроки присоединяются по коду\n" +
+		"*Один телефон* - все играют с твоего телефона, передавая его по кругу"
+	TextModeMultiDevice      = emoji.MobilePhone.String() + " Каждый со своего телефона"
+	TextModeHotSeat          = emoji.FamilyManWomanGirlBoy.String() + " Один телефон"
+	TextModeAnswer           = "Режим - %s"
+	TextHotSeatPlayersMsg    = emoji.PeopleHugging.String() + " Отправь имена игроков, можно несколько через запятую\n\nЧтобы убрать игрока, нажми на его имя"
+	TextHotSeatHostName      = "Ты"
+	TextHotSeatAddedPlayer   = "Добавлен игрок %s"
+	TextHotSeatDeletedPlayer = "Удален игрок %s"
+	TextHotSeatAddPlayers    = "Добавь хотя бы одного игрока"
+	TextStageMode            = "Режим"
+	TextStagePlayers         = "Игроки"
+	TextStageCategories      = "Категории"
+	TextStageRoundsNum       = "Раунды"
+	TextStageLetters         = "Буквы"
+	TextStageBloops          = "Блюпсы"
+	TextStageVote            = "Голосование"
+	TextStageScoring         = "Очки"
+	TextStageBoard           = "Табло"
+	TextStageTie             = "Ничья"
+	TextHotSeatReadyMsg      = emoji.Unicorn.String() + " Игра создана, все будут играть с этого телефона.\n\n" +
+		"Когда все соберутся, нажми " + emoji.Rocket.String() + " *Начать*"
 )
 
 // match text messages
@@ -89,13 +264,22 @@ var (
 	TextStopBtnDataAnswer                  = "Стоп!"
 	TextTimerBtnData                       = "Таймер"
 	TextStartLetterMsg                     = "Слова на букву - "
+	TextSuddenDeathMsg                     = emoji.CrossedSwords.String() + " Ничья! Дополнительный раунд между игроками: %s"
 	TextNextPlayerMsg                      = "*%s* - твоя очередь"
+	TextHotSeatPassMsg                     = emoji.MobilePhone.String() + " Передай телефон игроку *%s*"
+	TextSkipTurnMsg                        = "%s не начал раунд вовремя и пропускает ход"
 	TextPlayerLeftGameMsg                  = "Игрок %s покинул игру"
 	TextPlayerJoinedGameMsg                = "Игрок %s присоединился к игре"
 	TextStopPlayerRoundMsg                 = "Завершено! Ты набрал %d очков!"
 	TextGameStarted                        = "Игра началась!"
 	TextValidationRequiresMoreOnePlayerMsg = "Чтобы начать игру необходимо как минимум %d игрока. Ты можешь добавить виртуального игрока командой /add \n\nПодробнее для чего нужна команда /add можно посмотреть в /rules"
 	TextVoteMsg                            = "Голосование, игрок всё правильно назвал?"
+	TextVoteHostMsg                        = "Ты ведущий, реши, игрок всё правильно назвал?"
+	TextVoteTwiceAnswer                    = "Ты уже проголосовал"
+	TextVoteNotAllowedAnswer               = "Ты не участвуешь в этом голосовании"
+	TextVoteAcceptedMsg                    = "Ответ засчитан"
+	TextVoteRejectedMsg                    = "Ответ не засчитан"
 	TextBroadcastCrashMsg                  = "Из-за ошибки в работе сервиса игра была аварийно завершена, попробуйте создать игру заново"
 	TextStopButton                         = "Нажми Стоп, когда закончишь"
+	TextTypeWordsMsg                       = emoji.Pen.String() + " Пиши слова сообщениями, за каждое слово начисляются очки"
 )
@@ -0,0 +1,153 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewFake the clock that stands still until Advance is called
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now, changed: make(chan struct{})}
+}
+
+type Fake struct {
+	mtx     sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	// closed and replaced every time the set of waiters changes
+	changed chan struct{}
+}
+
+type fakeWaiter struct {
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+func (f *Fake) Now() time.Time {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.NewTimer(d).C()
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return &fakeTimer{f: f, w: f.addWaiter(d, 0)}
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	return &fakeTicker{f: f, w: f.addWaiter(d, d)}
+}
+
+// Advance moves the time forward and fires the timers and tickers that are due
+func (f *Fake) Advance(d time.Duration) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	end := f.now.Add(d)
+	for {
+		sort.Slice(f.waiters, func(i, j int) bool {
+			return f.waiters[i].at.Before(f.waiters[j].at)
+		})
+
+		if len(f.waiters) == 0 || f.waiters[0].at.After(end) {
+			break
+		}
+
+		w := f.waiters[0]
+		f.now = w.at
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			f.removeWaiter(w)
+		}
+	}
+
+	f.now = end
+}
+
+// BlockUntil waits until at least n timers and tickers are waiting for the time to pass
+func (f *Fake) BlockUntil(n int) {
+	for {
+		f.mtx.Lock()
+		waiters, changed := len(f.waiters), f.changed
+		f.mtx.Unlock()
+
+		if waiters >= n {
+			return
+		}
+
+		<-changed
+	}
+}
+
+func (f *Fake) addWaiter(d, period time.Duration) *fakeWaiter {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	w := &fakeWaiter{at: f.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	f.notify()
+
+	return w
+}
+
+func (f *Fake) removeWaiter(w *fakeWaiter) bool {
+	for i := range f.waiters {
+		if f.waiters[i] == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			f.notify()
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *Fake) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+type fakeTimer struct {
+	f *Fake
+	w *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.w.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.f.mtx.Lock()
+	defer t.f.mtx.Unlock()
+	return t.f.removeWaiter(t.w)
+}
+
+type fakeTicker struct {
+	f *Fake
+	w *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.w.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.f.mtx.Lock()
+	defer t.f.mtx.Unlock()
+	t.f.removeWaiter(t.w)
+}
@@ -0,0 +1,52 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeTimer(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(5 * time.Second)
+		close(done)
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(4 * time.Second)
+	select {
+	case <-done:
+		t.Fatalf("sleep returned before the time has passed")
+	default:
+	}
+
+	c.Advance(time.Second)
+	<-done
+
+	if got := c.Since(start); got != 5*time.Second {
+		t.Errorf("expected 5s, got %v", got)
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	t.Parallel()
+
+	c := NewFake(time.Now())
+	ticker := c.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 0; i < 3; i++ {
+		c.Advance(time.Second)
+		<-ticker.C()
+	}
+
+	timer := c.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Errorf("expected active timer to be stopped")
+	}
+}
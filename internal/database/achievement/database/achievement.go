@@ -0,0 +1,97 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bloops-games/bloops/internal/byteutil"
+	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/database/achievement/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+const prefix = "achievement"
+
+var (
+	pLen        = len(prefix)
+	ErrNotFound = fmt.Errorf("not found")
+)
+
+func New(db *database.DB) *DB {
+	return &DB{sDB: db}
+}
+
+type DB struct {
+	sDB *database.DB
+}
+
+func (db *DB) BytesBucket(userID int64) []byte {
+	b := make([]byte, pLen+2<<5) // prefix + uint64
+	copy(b, prefix[:])
+	copy(b[pLen:], byteutil.EncodeInt64ToBytes(userID))
+	return b
+}
+
+// FetchByUserID user achievements sorted by the time they were unlocked
+func (db *DB) FetchByUserID(userID int64) ([]model.Achievement, error) {
+	var list []model.Achievement
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(db.BytesBucket(userID))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var achievement model.Achievement
+			if err := json.Unmarshal(v, &achievement); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+			list = append(list, achievement)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("bucket for each: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+// Add the achievement kind is the key, so the same achievement is stored only once
+func (db *DB) Add(m model.Achievement) error {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b, err := tx.CreateBucketIfNotExists(db.BytesBucket(m.UserID))
+	if err != nil {
+		return fmt.Errorf("can not create bucket %d: %w", m.UserID, err)
+	}
+
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put([]byte(m.Kind), bytes); err != nil {
+		return fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+func NewAchievement(userID int64, kind string) Achievement {
+	return Achievement{UserID: userID, Kind: kind, CreatedAt: time.Now()}
+}
+
+type Achievement struct {
+	UserID    int64     `json:"userID"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+)
+
+// State the game settings the author has not finished yet
+type State struct {
+	AuthorID    int64               `json:"authorId"`
+	AuthorName  string              `json:"authorName"`
+	ChatID      int64               `json:"chatId"`
+	Stage       uint8               `json:"stage"`
+	Categories  []resource.Category `json:"categories"`
+	Letters     []resource.Letter   `json:"letters"`
+	RoundsNum   int                 `json:"roundsNum"`
+	RoundTime   int                 `json:"roundTime"`
+	Vote        bool                `json:"vote"`
+	VoteMode    uint8               `json:"voteMode"`
+	VoteTime    int                 `json:"voteTime"`
+	Bloops      bool                `json:"bloops"`
+	Scoring     uint8               `json:"scoring"`
+	HotSeat     bool                `json:"hotSeat"`
+	LiveBoard   bool                `json:"liveBoard"`
+	TieBreakers []uint8             `json:"tieBreakers"`
+	SuddenDeath bool                `json:"suddenDeath"`
+	PlayerNames []string            `json:"playerNames"`
+	CreatedAt   time.Time           `json:"createdAt"`
+}
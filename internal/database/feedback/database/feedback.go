@@ -0,0 +1,139 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bloops-games/bloops/internal/byteutil"
+	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/database/feedback/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+const bucket = "feedback"
+
+var ErrNotFound = fmt.Errorf("not found")
+
+func New(db *database.DB) *DB {
+	return &DB{sDB: db}
+}
+
+type DB struct {
+	sDB *database.DB
+}
+
+func pk(id uint64) []byte {
+	return byteutil.EncodeInt64ToBytes(int64(id))
+}
+
+// FetchPage newest feedback first, also returns the total number of feedback
+func (db *DB) FetchPage(offset, limit int) ([]model.Feedback, int, error) {
+	var list []model.Feedback
+	var total int
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		total = b.Stats().KeyN
+		c := b.Cursor()
+		idx := 0
+		for k, v := c.Last(); k != nil && len(list) < limit; k, v = c.Prev() {
+			if idx < offset {
+				idx++
+				continue
+			}
+
+			var feedback model.Feedback
+			if err := json.Unmarshal(v, &feedback); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+			list = append(list, feedback)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return list, total, nil
+}
+
+func (db *DB) Fetch(id uint64) (model.Feedback, error) {
+	var feedback model.Feedback
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		bytes := b.Get(pk(id))
+		if len(bytes) == 0 {
+			return ErrNotFound
+		}
+
+		if err := json.Unmarshal(bytes, &feedback); err != nil {
+			return fmt.Errorf("json unmarshal error, %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return feedback, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return feedback, nil
+}
+
+// Add stores the new feedback with the next bucket sequence as id
+func (db *DB) Add(m model.Feedback) (model.Feedback, error) {
+	if err := db.sDB.DB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return fmt.Errorf("can not create bucket %s: %w", bucket, err)
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return fmt.Errorf("next sequence: %w", err)
+		}
+
+		m.ID = id
+
+		return put(b, m)
+	}); err != nil {
+		return m, fmt.Errorf("update transaction error: %w", err)
+	}
+
+	return m, nil
+}
+
+func (db *DB) Store(m model.Feedback) error {
+	if err := db.sDB.DB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return fmt.Errorf("can not create bucket %s: %w", bucket, err)
+		}
+
+		return put(b, m)
+	}); err != nil {
+		return fmt.Errorf("update transaction error: %w", err)
+	}
+
+	return nil
+}
+
+func put(b *bolt.Bucket, m model.Feedback) error {
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put(pk(m.ID), bytes); err != nil {
+		return fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	return nil
+}
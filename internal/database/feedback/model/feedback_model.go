@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+type Status uint8
+
+const (
+	StatusNew Status = iota + 1
+	StatusRead
+	StatusAnswered
+)
+
+func NewFeedback(userID, chatID int64, text string) Feedback {
+	return Feedback{
+		UserID:    userID,
+		ChatID:    chatID,
+		Text:      text,
+		Status:    StatusNew,
+		CreatedAt: time.Now(),
+	}
+}
+
+type Feedback struct {
+	ID         uint64    `json:"id"`
+	UserID     int64     `json:"userID"`
+	ChatID     int64     `json:"chatID"`
+	Text       string    `json:"text"`
+	Status     Status    `json:"status"`
+	Answer     string    `json:"answer"`
+	CreatedAt  time.Time `json:"createdAt"`
+	AnsweredAt time.Time `json:"answeredAt"`
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/byteutil"
+	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/database/guest/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+const prefix = "guests"
+
+var ErrNotFound = fmt.Errorf("not found")
+
+func New(db *database.DB) *DB {
+	return &DB{sDB: db}
+}
+
+type DB struct {
+	sDB *database.DB
+}
+
+// FetchByOwnerID guests of the owner sorted by the time they were added
+func (db *DB) FetchByOwnerID(ownerID int64) ([]model.Guest, error) {
+	var list []model.Guest
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(prefix))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var guest model.Guest
+			if err := json.Unmarshal(v, &guest); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+
+			if guest.OwnerID == ownerID {
+				list = append(list, guest)
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("bucket for each: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+func (db *DB) Fetch(id int64) (model.Guest, error) {
+	var guest model.Guest
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(prefix))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		v := b.Get(byteutil.EncodeInt64ToBytes(id))
+		if v == nil {
+			return ErrNotFound
+		}
+
+		if err := json.Unmarshal(v, &guest); err != nil {
+			return fmt.Errorf("json unmarshal error, %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return guest, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return guest, nil
+}
+
+// FetchOrAdd the guest of the owner with the same name is reused, otherwise a new guest gets the next id
+func (db *DB) FetchOrAdd(m model.Guest) (model.Guest, error) {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return m, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b, err := tx.CreateBucketIfNotExists([]byte(prefix))
+	if err != nil {
+		return m, fmt.Errorf("can not create bucket: %w", err)
+	}
+
+	var found *model.Guest
+	if err := b.ForEach(func(k, v []byte) error {
+		var guest model.Guest
+		if err := json.Unmarshal(v, &guest); err != nil {
+			return fmt.Errorf("json unmarshal error, %w", err)
+		}
+
+		if guest.OwnerID == m.OwnerID && strings.EqualFold(guest.Name, m.Name) {
+			found = &guest
+		}
+
+		return nil
+	}); err != nil {
+		return m, fmt.Errorf("bucket for each: %w", err)
+	}
+
+	if found != nil {
+		return *found, nil
+	}
+
+	seq, err := b.NextSequence()
+	if err != nil {
+		return m, fmt.Errorf("next sequence: %w", err)
+	}
+
+	m.ID = -int64(seq)
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return m, fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put(byteutil.EncodeInt64ToBytes(m.ID), bytes); err != nil {
+		return m, fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return m, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return m, nil
+}
+
+func (db *DB) Delete(id int64) error {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b := tx.Bucket([]byte(prefix))
+	if b == nil {
+		return ErrNotFound
+	}
+
+	if err := b.Delete(byteutil.EncodeInt64ToBytes(id)); err != nil {
+		return fmt.Errorf("delete from bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+func NewGuest(ownerID int64, name string) Guest {
+	return Guest{OwnerID: ownerID, Name: name, CreatedAt: time.Now()}
+}
+
+// Guest the player without telegram who plays from the phone of the owner
+type Guest struct {
+	// synthetic id, always negative so it never matches the telegram user id
+	ID        int64     `json:"id"`
+	OwnerID   int64     `json:"ownerId"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
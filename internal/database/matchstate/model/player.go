@@ -15,6 +15,19 @@ const (
 	PlayerStateKindLeaving
 )
 
+// NewGuestPlayer the guest has its own synthetic id and is controlled by the owner
+func NewGuestPlayer(chatID int64, owner userModel.User, guestID int64, name string) *Player {
+	return &Player{
+		User:    userModel.User{ID: guestID, FirstName: name},
+		UserID:  guestID,
+		OwnerID: owner.ID,
+		ChatID:  chatID,
+		Rates:   []*Rate{},
+		State:   PlayerStateKindPlaying,
+		Offline: true,
+	}
+}
+
 func NewPlayer(chatID int64, user userModel.User, offline bool) *Player {
 	return &Player{
 		User:    user,
@@ -32,7 +45,22 @@ type Player struct {
 	Offline bool            `json:"offline"`
 	ChatID  int64           `json:"chatId"`
 	UserID  int64           `json:"userID"`
-	Rates   []*Rate         `json:"rates"`
+	// the telegram user who plays for the guest, zero for the players with their own telegram
+	OwnerID int64   `json:"ownerId"`
+	Rates   []*Rate `json:"rates"`
+}
+
+func (p *Player) IsGuest() bool {
+	return p.OwnerID != 0 && p.OwnerID != p.UserID
+}
+
+// ControllerID the telegram user whose messages and clicks act for the player
+func (p *Player) ControllerID() int64 {
+	if p.IsGuest() {
+		return p.OwnerID
+	}
+
+	return p.UserID
 }
 
 func (p *Player) IsPlaying() bool {
@@ -8,4 +8,27 @@ type Rate struct {
 	Completed  bool          `json:"completed"`
 	Bloops     bool          `json:"bloopsbot"`
 	BloopsName string        `json:"bloopsName"`
+	Words      int           `json:"words"`
+	// the drawn letter and the categories in play, empty for the skipped turn
+	Letter     string   `json:"letter"`
+	Categories []string `json:"categories"`
+	// the result of the turn before the vote and the corrections, the accepted re-opened vote returns to it
+	ScoredPoints    int  `json:"scoredPoints"`
+	ScoredCompleted bool `json:"scoredCompleted"`
+	// the changes of the points made by the host after the turn, the oldest first
+	Corrections []Correction `json:"corrections,omitempty"`
+}
+
+// Correction the points of the round before and after the change
+type Correction struct {
+	OriginalPoints int       `json:"originalPoints"`
+	AdjustedPoints int       `json:"adjustedPoints"`
+	Reason         string    `json:"reason"`
+	Revote         bool      `json:"revote"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// IsCorrected the host has changed the points after the turn
+func (r *Rate) IsCorrected() bool {
+	return len(r.Corrections) > 0
 }
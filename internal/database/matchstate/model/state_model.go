@@ -16,7 +16,17 @@ type State struct {
 	Letters    []string          `json:"letters"`
 	Bloopses   []resource.Bloops `json:"bloopses"`
 	Vote       bool              `json:"vote"`
+	VoteMode   uint8             `json:"voteMode"`
+	VoteTime   int               `json:"voteTime"`
 	Code       int64             `json:"code"`
+	Scoring    uint8             `json:"scoring"`
+	HotSeat    bool              `json:"hotSeat"`
+	LiveBoard  bool              `json:"liveBoard"`
+	// the values of match.TieBreaker
+	TieBreakers []uint8 `json:"tieBreakers"`
+	SuddenDeath bool    `json:"suddenDeath"`
+	ViewToken   string  `json:"viewToken"`
+	Seed        int64   `json:"seed"`
 
 	State        uint8     `json:"state"`
 	CurrRoundIdx int       `json:"currRoundIdx"`
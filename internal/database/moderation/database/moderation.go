@@ -0,0 +1,93 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bloops-games/bloops/internal/byteutil"
+	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/database/moderation/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+const bucket = "moderation"
+
+var ErrNotFound = fmt.Errorf("not found")
+
+func New(db *database.DB) *DB {
+	return &DB{sDB: db}
+}
+
+// DB audit log of the moderation actions, records are only appended and never changed
+type DB struct {
+	sDB *database.DB
+}
+
+// FetchByUserID moderation history of the user in the order of the actions
+func (db *DB) FetchByUserID(userID int64) ([]model.Record, error) {
+	var list []model.Record
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var record model.Record
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+
+			if record.UserID == userID {
+				list = append(list, record)
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("bucket for each: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return list, nil
+}
+
+// Append the key is the bucket sequence, so the records keep the order of the actions
+func (db *DB) Append(m model.Record) error {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return fmt.Errorf("can not create bucket %s: %w", bucket, err)
+	}
+
+	id, err := b.NextSequence()
+	if err != nil {
+		return fmt.Errorf("next sequence: %w", err)
+	}
+
+	m.ID = id
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put(byteutil.EncodeInt64ToBytes(int64(id)), bytes); err != nil {
+		return fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
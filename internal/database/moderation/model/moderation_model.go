@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type Action uint8
+
+const (
+	ActionBan Action = iota + 1
+	ActionUnban
+	// the timed ban ended by itself
+	ActionExpire
+)
+
+func NewRecord(action Action, adminID, userID int64, username, reason string, until time.Time) Record {
+	return Record{
+		Action:    action,
+		AdminID:   adminID,
+		UserID:    userID,
+		Username:  username,
+		Reason:    reason,
+		Until:     until,
+		CreatedAt: time.Now(),
+	}
+}
+
+type Record struct {
+	ID       uint64 `json:"id"`
+	Action   Action `json:"action"`
+	AdminID  int64  `json:"adminID"`
+	UserID   int64  `json:"userID"`
+	Username string `json:"username"`
+	Reason   string `json:"reason"`
+	// zero value means a permanent ban
+	Until     time.Time `json:"until"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bloops-games/bloops/internal/byteutil"
+	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/database/preset/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+const prefix = "preset"
+
+var (
+	pLen        = len(prefix)
+	ErrNotFound = fmt.Errorf("not found")
+)
+
+func New(db *database.DB) *DB {
+	return &DB{sDB: db}
+}
+
+type DB struct {
+	sDB *database.DB
+}
+
+func (db *DB) BytesBucket(userID int64) []byte {
+	b := make([]byte, pLen+2<<5) // prefix + uint64
+	copy(b, prefix[:])
+	copy(b[pLen:], byteutil.EncodeInt64ToBytes(userID))
+	return b
+}
+
+// FetchByUserID user presets sorted by name
+func (db *DB) FetchByUserID(userID int64) ([]model.Preset, error) {
+	var list []model.Preset
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(db.BytesBucket(userID))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var preset model.Preset
+			if err := json.Unmarshal(v, &preset); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+			list = append(list, preset)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("bucket for each: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func (db *DB) Fetch(userID int64, name string) (model.Preset, error) {
+	var preset model.Preset
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(db.BytesBucket(userID))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		v := b.Get([]byte(name))
+		if v == nil {
+			return ErrNotFound
+		}
+
+		if err := json.Unmarshal(v, &preset); err != nil {
+			return fmt.Errorf("json unmarshal error, %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return preset, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return preset, nil
+}
+
+// Add the name is the key, the preset with the same name is replaced
+func (db *DB) Add(m model.Preset) error {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b, err := tx.CreateBucketIfNotExists(db.BytesBucket(m.UserID))
+	if err != nil {
+		return fmt.Errorf("can not create bucket %d: %w", m.UserID, err)
+	}
+
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put([]byte(m.Name), bytes); err != nil {
+		return fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) Delete(userID int64, name string) error {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b := tx.Bucket(db.BytesBucket(userID))
+	if b == nil {
+		return ErrNotFound
+	}
+
+	if err := b.Delete([]byte(name)); err != nil {
+		return fmt.Errorf("delete from bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+)
+
+// Preset the game settings saved by the user under a name to start the same game in one tap
+type Preset struct {
+	UserID      int64               `json:"userId"`
+	Name        string              `json:"name"`
+	Categories  []resource.Category `json:"categories"`
+	Letters     []resource.Letter   `json:"letters"`
+	RoundsNum   int                 `json:"roundsNum"`
+	RoundTime   int                 `json:"roundTime"`
+	Vote        bool                `json:"vote"`
+	VoteMode    uint8               `json:"voteMode"`
+	VoteTime    int                 `json:"voteTime"`
+	Bloops      bool                `json:"bloops"`
+	Scoring     uint8               `json:"scoring"`
+	HotSeat     bool                `json:"hotSeat"`
+	LiveBoard   bool                `json:"liveBoard"`
+	TieBreakers []uint8             `json:"tieBreakers"`
+	SuddenDeath bool                `json:"suddenDeath"`
+	PlayerNames []string            `json:"playerNames"`
+	CreatedAt   time.Time           `json:"createdAt"`
+}
@@ -9,10 +9,16 @@ import (
 	"github.com/bloops-games/bloops/internal/cache"
 	"github.com/bloops-games/bloops/internal/database"
 	"github.com/bloops-games/bloops/internal/database/stat/model"
+	"github.com/google/uuid"
 	bolt "go.etcd.io/bbolt"
 )
 
-const prefix = "stat"
+const (
+	prefix = "stat"
+	// the single bucket of the performance of all players
+	difficultyBucket = "difficulty"
+	difficultyKey    = "global"
+)
 
 var (
 	pLen        = len(prefix)
@@ -67,7 +73,7 @@ func (db *DB) FetchRateStat(userID int64) (model.RateStat, error) {
 }
 
 func (db *DB) FetchProfileStat(userID int64) (model.AggregationStat, error) {
-	var aggregationStat model.AggregationStat
+	aggregationStat := model.AggregationStat{Letters: model.Performances{}, Categories: model.Performances{}}
 	var sumPoints, pointsNum int
 	var sumDuration time.Duration
 
@@ -96,6 +102,9 @@ func (db *DB) FetchProfileStat(userID int64) (model.AggregationStat, error) {
 			aggregationStat.WorstDuration = stat.WorstDuration
 		}
 
+		aggregationStat.Letters.Merge(stat.LetterPerformance)
+		aggregationStat.Categories.Merge(stat.CategoryPerformance)
+
 		sumDuration += stat.SumDuration
 		sumPoints += stat.SumPoints
 		pointsNum += 1
@@ -148,6 +157,14 @@ func (db *DB) FetchByuserID(userID int64) ([]model.Stat, error) {
 			if err := json.Unmarshal(v, &metric); err != nil {
 				return fmt.Errorf("json unmarshal error, %w", err)
 			}
+
+			// the id is not marshaled, it is the key of the stat
+			id, err := uuid.FromBytes(k)
+			if err != nil {
+				return fmt.Errorf("parse stat id: %w", err)
+			}
+
+			metric.ID = id
 			list = append(list, metric)
 			return nil
 		}); err != nil {
@@ -210,3 +227,78 @@ func (db *DB) Add(m model.Stat) error {
 
 	return nil
 }
+
+// FetchDifficulty the performance of all players by letters and categories
+func (db *DB) FetchDifficulty() (model.Difficulty, error) {
+	difficulty := model.Difficulty{Letters: model.Performances{}, Categories: model.Performances{}}
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(difficultyBucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		v := b.Get([]byte(difficultyKey))
+		if v == nil {
+			return ErrNotFound
+		}
+
+		if err := json.Unmarshal(v, &difficulty); err != nil {
+			return fmt.Errorf("json unmarshal error, %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return difficulty, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return difficulty, nil
+}
+
+// AddDifficulty merges the performance of the finished game into the global one
+func (db *DB) AddDifficulty(m model.Difficulty) error {
+	tx, err := db.sDB.DB.Begin(true)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint
+
+	b, err := tx.CreateBucketIfNotExists([]byte(difficultyBucket))
+	if err != nil {
+		return fmt.Errorf("can not create bucket: %w", err)
+	}
+
+	difficulty := model.Difficulty{Letters: model.Performances{}, Categories: model.Performances{}}
+	if v := b.Get([]byte(difficultyKey)); v != nil {
+		if err := json.Unmarshal(v, &difficulty); err != nil {
+			return fmt.Errorf("json unmarshal error, %w", err)
+		}
+	}
+
+	if difficulty.Letters == nil {
+		difficulty.Letters = model.Performances{}
+	}
+
+	if difficulty.Categories == nil {
+		difficulty.Categories = model.Performances{}
+	}
+
+	difficulty.Letters.Merge(m.Letters)
+	difficulty.Categories.Merge(m.Categories)
+
+	bytes, err := json.Marshal(difficulty)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put([]byte(difficultyKey), bytes); err != nil {
+		return fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
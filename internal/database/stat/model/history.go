@@ -0,0 +1,57 @@
+package model
+
+import "sort"
+
+// SortByDate the latest games first
+func SortByDate(stats []Stat) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].CreatedAt.After(stats[j].CreatedAt)
+	})
+}
+
+// FindCoPlayer the result of the other player in the game of the stat
+func (s Stat) FindCoPlayer(userID int64) (CoPlayer, bool) {
+	for _, p := range s.CoPlayers {
+		if p.UserID == userID {
+			return p, true
+		}
+	}
+
+	return CoPlayer{}, false
+}
+
+// HeadToHead the games of the player against the opponent, the higher place wins
+type HeadToHead struct {
+	Games          int
+	Wins           int
+	Losses         int
+	Draws          int
+	Points         int
+	OpponentPoints int
+}
+
+// NewHeadToHead the stats of the player are enough, the opponent is found among the co-players
+func NewHeadToHead(stats []Stat, opponentID int64) HeadToHead {
+	var h HeadToHead
+	for _, stat := range stats {
+		opponent, ok := stat.FindCoPlayer(opponentID)
+		if !ok {
+			continue
+		}
+
+		h.Games++
+		h.Points += stat.SumPoints
+		h.OpponentPoints += opponent.Points
+
+		switch {
+		case stat.Place < opponent.Place:
+			h.Wins++
+		case stat.Place > opponent.Place:
+			h.Losses++
+		default:
+			h.Draws++
+		}
+	}
+
+	return h
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewHeadToHead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	stats := []Stat{
+		{SumPoints: 10, Place: 1, CreatedAt: now.Add(-time.Hour), CoPlayers: []CoPlayer{{UserID: 2, Points: 5, Place: 2}}},
+		{SumPoints: 3, Place: 2, CreatedAt: now, CoPlayers: []CoPlayer{{UserID: 2, Points: 8, Place: 1}, {UserID: 3, Points: 1, Place: 3}}},
+		{SumPoints: 7, Place: 1, CreatedAt: now.Add(-2 * time.Hour), CoPlayers: []CoPlayer{{UserID: 2, Points: 7, Place: 1}}},
+		{SumPoints: 20, Place: 1, CreatedAt: now.Add(-3 * time.Hour), CoPlayers: []CoPlayer{{UserID: 3, Points: 1, Place: 2}}},
+		// the stat of the game played before the history
+		{SumPoints: 30, CreatedAt: now.Add(-4 * time.Hour)},
+	}
+
+	expected := HeadToHead{Games: 3, Wins: 1, Losses: 1, Draws: 1, Points: 20, OpponentPoints: 20}
+	if h := NewHeadToHead(stats, 2); h != expected {
+		t.Errorf("expected %+v, got %+v", expected, h)
+	}
+
+	if h := NewHeadToHead(stats, 4); h != (HeadToHead{}) {
+		t.Errorf("expected no games, got %+v", h)
+	}
+
+	SortByDate(stats)
+	for i := 1; i < len(stats); i++ {
+		if stats[i].CreatedAt.After(stats[i-1].CreatedAt) {
+			t.Fatalf("expected the latest games first, got %v after %v", stats[i].CreatedAt, stats[i-1].CreatedAt)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Performance rounds played with the same letter or category
+type Performance struct {
+	Rounds      int           `json:"rounds"`
+	Completed   int           `json:"completed"`
+	SumDuration time.Duration `json:"sumDuration"`
+}
+
+// CompletionRate share of the completed rounds from 0 to 1
+func (p Performance) CompletionRate() float64 {
+	if p.Rounds == 0 {
+		return 0
+	}
+
+	return float64(p.Completed) / float64(p.Rounds)
+}
+
+func (p Performance) AvgDuration() time.Duration {
+	if p.Rounds == 0 {
+		return 0
+	}
+
+	return p.SumDuration / time.Duration(p.Rounds)
+}
+
+// Performances performance by the letter or the category name
+type Performances map[string]Performance
+
+func (ps Performances) Add(key string, completed bool, duration time.Duration) {
+	p := ps[key]
+	p.Rounds++
+	p.SumDuration += duration
+	if completed {
+		p.Completed++
+	}
+
+	ps[key] = p
+}
+
+func (ps Performances) Merge(other Performances) {
+	for key, o := range other {
+		p := ps[key]
+		p.Rounds += o.Rounds
+		p.Completed += o.Completed
+		p.SumDuration += o.SumDuration
+		ps[key] = p
+	}
+}
+
+// Hardest the keys with the lowest completion rate, the longer rounds go first on a tie,
+// keys with less than minRounds rounds are not reliable and skipped
+func (ps Performances) Hardest(n, minRounds int) []string {
+	keys := make([]string, 0, len(ps))
+	for key, p := range ps {
+		if p.Rounds >= minRounds {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		pi, pj := ps[keys[i]], ps[keys[j]]
+		if ri, rj := pi.CompletionRate(), pj.CompletionRate(); ri != rj {
+			return ri < rj
+		}
+
+		if di, dj := pi.AvgDuration(), pj.AvgDuration(); di != dj {
+			return di > dj
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	return keys
+}
+
+// Difficulty the performance of all players, shared between the games
+type Difficulty struct {
+	Letters    Performances `json:"letters"`
+	Categories Performances `json:"categories"`
+}
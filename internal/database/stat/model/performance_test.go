@@ -0,0 +1,53 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPerformancesHardest(t *testing.T) {
+	t.Parallel()
+
+	ps := Performances{}
+	ps.Add("А", true, 10*time.Second)
+	ps.Add("А", true, 20*time.Second)
+	ps.Add("Ъ", false, 60*time.Second)
+	ps.Add("Ъ", true, 40*time.Second)
+	ps.Add("Ы", false, 30*time.Second)
+	ps.Add("Ы", true, 30*time.Second)
+	ps.Add("Й", false, 60*time.Second)
+
+	if p := ps["Ъ"]; p.CompletionRate() != 0.5 || p.AvgDuration() != 50*time.Second {
+		t.Fatalf("unexpected performance %+v", p)
+	}
+
+	testCases := []struct {
+		name      string
+		n         int
+		minRounds int
+		expected  []string
+	}{
+		{name: "longer_first_on_tie", n: 3, minRounds: 2, expected: []string{"Ъ", "Ы", "А"}},
+		{name: "limited", n: 1, minRounds: 2, expected: []string{"Ъ"}},
+		{name: "single_round_counted", n: 2, minRounds: 1, expected: []string{"Й", "Ъ"}},
+		{name: "not_enough_rounds", n: 3, minRounds: 3, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ps.Hardest(tc.n, tc.minRounds); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	merged := Performances{}
+	merged.Merge(ps)
+	merged.Merge(Performances{"А": {Rounds: 2}})
+	if p := merged["А"]; p.Rounds != 4 || p.Completed != 2 {
+		t.Errorf("unexpected merged performance %+v", p)
+	}
+}
@@ -31,13 +31,32 @@ type Stat struct {
 	WorstPoints   int `json:"worstPoints"`
 	BestPoints    int `json:"bestPoints"`
 
-	RoundsNum  int       `json:"roundsNum"`
-	Conclusion Status    `json:"conclusion"`
-	Categories []string  `json:"categories"`
-	Bloops     []string  `json:"bloopsbot"`
-	PlayersNum int       `json:"playersNum"`
-	Vote       bool      `json:"vote"`
-	CreatedAt  time.Time `json:"createdAt"`
+	RoundsNum  int      `json:"roundsNum"`
+	Conclusion Status   `json:"conclusion"`
+	Categories []string `json:"categories"`
+	Bloops     []string `json:"bloopsbot"`
+	// number of bloopses the player managed to complete
+	CompletedBloops int `json:"completedBloops"`
+	// rounds whose points were changed by the host, the points above are the corrected ones
+	CorrectedRounds int       `json:"correctedRounds"`
+	PlayersNum      int       `json:"playersNum"`
+	Vote            bool      `json:"vote"`
+	CreatedAt       time.Time `json:"createdAt"`
+	// completion and time of the rounds by the drawn letter and by the category in play
+	LetterPerformance   Performances `json:"letterPerformance"`
+	CategoryPerformance Performances `json:"categoryPerformance"`
+	// the match and the other players of it, empty in the stats of the games played before the history
+	MatchCode int64      `json:"matchCode"`
+	Place     int        `json:"place"`
+	CoPlayers []CoPlayer `json:"coPlayers"`
+}
+
+// CoPlayer the result of the other player of the same match
+type CoPlayer struct {
+	UserID int64  `json:"userID"`
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+	Place  int    `json:"place"`
 }
 
 type RateStat struct {
@@ -55,4 +74,6 @@ type AggregationStat struct {
 	AvgPoints     int
 	BestPoints    int
 	WorstPoints   int
+	Letters       Performances
+	Categories    Performances
 }
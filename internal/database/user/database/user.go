@@ -85,6 +85,33 @@ func (db *DB) FetchByUsername(username string) (model.User, error) {
 	return user, nil
 }
 
+func (db *DB) FetchAll() ([]model.User, error) {
+	var list []model.User
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var u model.User
+			if err := json.Unmarshal(v, &u); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+			list = append(list, u)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("bucket for each: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return list, nil
+}
+
 func (db *DB) Fetch(userID int64) (model.User, error) {
 	var u model.User
 	pk := byteutil.EncodeInt64ToBytes(userID)
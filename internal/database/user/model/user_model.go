@@ -18,6 +18,19 @@ type User struct {
 	Username     string    `json:"username"`
 	CreatedAt    time.Time `json:"createdAt"`
 	Status       Status    `json:"banned"`
-	Stars        int
-	Bloops       int
+	BlockedBot   bool      `json:"blockedBot"`
+	// zero value with StatusBanned means a permanent ban
+	BannedUntil time.Time `json:"bannedUntil"`
+	BanReason   string    `json:"banReason"`
+	Stars       int
+	Bloops      int
+}
+
+// IsBanned a timed ban is over after BannedUntil
+func (u User) IsBanned(now time.Time) bool {
+	if u.Status != StatusBanned {
+		return false
+	}
+
+	return u.BannedUntil.IsZero() || now.Before(u.BannedUntil)
 }
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bloops-games/bloops/internal/byteutil"
+	"github.com/bloops-games/bloops/internal/database"
+	"github.com/bloops-games/bloops/internal/database/webhook/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+const bucket = "webhook"
+
+func New(db *database.DB) *DB {
+	return &DB{sDB: db}
+}
+
+// DB queue of the webhook deliveries, a delivery is removed once the receiver accepted it
+type DB struct {
+	sDB *database.DB
+}
+
+func pk(id uint64) []byte {
+	return byteutil.EncodeInt64ToBytes(int64(id))
+}
+
+// FetchAll deliveries in the order the events were emitted
+func (db *DB) FetchAll() ([]model.Delivery, error) {
+	var list []model.Delivery
+
+	if err := db.sDB.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var delivery model.Delivery
+			if err := json.Unmarshal(v, &delivery); err != nil {
+				return fmt.Errorf("json unmarshal error, %w", err)
+			}
+			list = append(list, delivery)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("bucket for each: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("view transaction error: %w", err)
+	}
+
+	return list, nil
+}
+
+// Add stores the new delivery with the next bucket sequence as id
+func (db *DB) Add(m model.Delivery) (model.Delivery, error) {
+	if err := db.sDB.DB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return fmt.Errorf("can not create bucket %s: %w", bucket, err)
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return fmt.Errorf("next sequence: %w", err)
+		}
+
+		m.ID = id
+
+		return put(b, m)
+	}); err != nil {
+		return m, fmt.Errorf("update transaction error: %w", err)
+	}
+
+	return m, nil
+}
+
+func (db *DB) Store(m model.Delivery) error {
+	if err := db.sDB.DB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return fmt.Errorf("can not create bucket %s: %w", bucket, err)
+		}
+
+		return put(b, m)
+	}); err != nil {
+		return fmt.Errorf("update transaction error: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) Delete(id uint64) error {
+	if err := db.sDB.DB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		if err := b.Delete(pk(id)); err != nil {
+			return fmt.Errorf("delete from bucket error: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("update transaction error: %w", err)
+	}
+
+	return nil
+}
+
+func put(b *bolt.Bucket, m model.Delivery) error {
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := b.Put(pk(m.ID), bytes); err != nil {
+		return fmt.Errorf("put to bucket error: %w", err)
+	}
+
+	return nil
+}
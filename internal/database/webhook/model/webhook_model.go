@@ -0,0 +1,22 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+func NewDelivery(event string, payload json.RawMessage) Delivery {
+	now := time.Now()
+	return Delivery{Event: event, Payload: payload, CreatedAt: now, NextAttemptAt: now}
+}
+
+// Delivery webhook event waiting to be delivered to the receiver
+type Delivery struct {
+	ID            uint64          `json:"id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"lastError"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+}
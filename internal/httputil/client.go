@@ -3,6 +3,7 @@ package httputil
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -43,3 +44,22 @@ func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, e
 	req.SetBasicAuth(rt.username, strings.TrimSpace(rt.password))
 	return rt.rt.RoundTrip(req)
 }
+
+type endpointRoundTripper struct {
+	endpoint *url.URL
+	rt       http.RoundTripper
+}
+
+// NewEndpointRoundTripper sends all requests to the endpoint keeping the path, e.g. to a local API stand-in
+func NewEndpointRoundTripper(endpoint *url.URL, rt http.RoundTripper) http.RoundTripper {
+	return &endpointRoundTripper{endpoint, rt}
+}
+
+func (rt *endpointRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.endpoint.Scheme
+	req.URL.Host = rt.endpoint.Host
+	req.URL.Path = strings.TrimSuffix(rt.endpoint.Path, "/") + req.URL.Path
+	req.Host = rt.endpoint.Host
+	return rt.rt.RoundTrip(req)
+}
@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+)
+
+var scoringKinds = map[string]match.ScoringKind{
+	"seconds": match.ScoringKindSeconds,
+	"flat":    match.ScoringKindFlat,
+	"tiers":   match.ScoringKindTiers,
+	"words":   match.ScoringKindWords,
+}
+
+type Config struct {
+	Matches   int
+	RoundsNum int
+	// seconds of the usual turn
+	RoundTime int
+	Scoring   match.ScoringKind
+	// the bloopses from the game resources drop during the turns
+	Bloops  bool
+	Players []PlayerModel
+	// seed of the first match, the next matches use seed+1, seed+2...
+	Seed int64
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.Matches <= 0:
+		return fmt.Errorf("matches must be positive")
+	case c.RoundsNum <= 0:
+		return fmt.Errorf("rounds must be positive")
+	case c.RoundTime <= 0:
+		return fmt.Errorf("round time must be positive")
+	case len(c.Players) == 0:
+		return fmt.Errorf("no players")
+	}
+
+	return nil
+}
+
+// PlayerModel the synthetic player, all times are in seconds
+type PlayerModel struct {
+	Name string `json:"name"`
+	// time to press the start button after the turn is passed
+	StartDelay float64 `json:"startDelay"`
+	// mean time to name the words for all the categories and its standard deviation
+	ReactionTime   float64 `json:"reactionTime"`
+	ReactionSpread float64 `json:"reactionSpread"`
+	// probability to finish the usual turn
+	Completion float64 `json:"completion"`
+	// probability to finish the turn with a bloops, Completion is used if zero
+	BloopsCompletion float64 `json:"bloopsCompletion"`
+	// per bloops probability, the key is a part of the bloops name
+	Bloopses map[string]float64 `json:"bloopses"`
+	// typing speed for the words scoring
+	WordsPerSecond float64 `json:"wordsPerSecond"`
+}
+
+func (p PlayerModel) completion(bloopsName string) float64 {
+	if bloopsName == "" {
+		return p.Completion
+	}
+
+	for name, completion := range p.Bloopses {
+		if strings.Contains(bloopsName, name) {
+			return completion
+		}
+	}
+
+	if p.BloopsCompletion > 0 {
+		return p.BloopsCompletion
+	}
+
+	return p.Completion
+}
+
+func ParseScoring(s string) (match.ScoringKind, error) {
+	kind, ok := scoringKinds[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown scoring %q", s)
+	}
+
+	return kind, nil
+}
@@ -0,0 +1,223 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+)
+
+const (
+	MetricPointsPerRound   = "points_per_round"
+	MetricBloopsCompletion = "bloops_completion"
+	MetricBloopsPoints     = "bloops_points"
+	MetricWinnerMargin     = "winner_margin"
+	MetricGameDuration     = "game_duration_sec"
+)
+
+var reportHeader = []string{"metric", "name", "count", "mean", "min", "p50", "p90", "max"}
+
+// Distribution the summary of the samples, the completion metrics are 0/1 samples so the mean is the rate
+type Distribution struct {
+	Metric string
+	// bloops name for the per bloops metrics
+	Name  string
+	Count int
+	Mean  float64
+	Min   float64
+	P50   float64
+	P90   float64
+	Max   float64
+}
+
+type Report struct {
+	Matches int
+	Seed    int64
+	Rows    []Distribution
+}
+
+func (r Report) Row(metric, name string) (Distribution, bool) {
+	for _, row := range r.Rows {
+		if row.Metric == metric && row.Name == name {
+			return row, true
+		}
+	}
+
+	return Distribution{}, false
+}
+
+func (r Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintf(tw, "matches: %d, seed: %d\n\n", r.Matches, r.Seed); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	for i, col := range reportHeader {
+		sep := "\t"
+		if i == len(reportHeader)-1 {
+			sep = "\n"
+		}
+
+		if _, err := fmt.Fprint(tw, col, sep); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+	}
+
+	for _, row := range r.Rows {
+		if _, err := fmt.Fprintf(
+			tw,
+			"%s\t%s\t%d\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			row.Metric, row.Name, row.Count, row.Mean, row.Min, row.P50, row.P90, row.Max,
+		); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportHeader); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	for _, row := range r.Rows {
+		if err := cw.Write([]string{
+			row.Metric,
+			row.Name,
+			strconv.Itoa(row.Count),
+			formatFloat(row.Mean),
+			formatFloat(row.Min),
+			formatFloat(row.P50),
+			formatFloat(row.P90),
+			formatFloat(row.Max),
+		}); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
+
+func newCollector() *collector {
+	return &collector{
+		bloopsCompletion: map[string][]float64{},
+		bloopsPoints:     map[string][]float64{},
+	}
+}
+
+type collector struct {
+	matches          int
+	points           []float64
+	completion       []float64
+	margins          []float64
+	durations        []float64
+	bloopsCompletion map[string][]float64
+	bloopsPoints     map[string][]float64
+}
+
+func (c *collector) addTurn(turn match.Turn) {
+	c.points = append(c.points, float64(turn.Rate.Points))
+	if turn.Bloops == nil {
+		return
+	}
+
+	var completed float64
+	if turn.Rate.Completed {
+		completed = 1
+	}
+
+	name := turn.Bloops.Name
+	c.completion = append(c.completion, completed)
+	c.bloopsCompletion[name] = append(c.bloopsCompletion[name], completed)
+	c.bloopsPoints[name] = append(c.bloopsPoints[name], float64(turn.Rate.Points))
+}
+
+func (c *collector) addMatch(margin int, duration float64) {
+	c.matches++
+	c.margins = append(c.margins, float64(margin))
+	c.durations = append(c.durations, duration)
+}
+
+func (c *collector) report(config Config) Report {
+	r := Report{Matches: c.matches, Seed: config.Seed}
+	r.Rows = append(r.Rows,
+		summarize(MetricPointsPerRound, "", c.points),
+		summarize(MetricWinnerMargin, "", c.margins),
+		summarize(MetricGameDuration, "", c.durations),
+	)
+
+	if !config.Bloops {
+		return r
+	}
+
+	r.Rows = append(r.Rows, summarize(MetricBloopsCompletion, "", c.completion))
+
+	names := make([]string, 0, len(c.bloopsCompletion))
+	for name := range c.bloopsCompletion {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r.Rows = append(r.Rows, summarize(MetricBloopsCompletion, name, c.bloopsCompletion[name]))
+	}
+
+	for _, name := range names {
+		r.Rows = append(r.Rows, summarize(MetricBloopsPoints, name, c.bloopsPoints[name]))
+	}
+
+	return r
+}
+
+func summarize(metric, name string, samples []float64) Distribution {
+	d := Distribution{Metric: metric, Name: name, Count: len(samples)}
+	if len(samples) == 0 {
+		return d
+	}
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+
+	d.Mean = sum / float64(len(sorted))
+	d.Min = sorted[0]
+	d.Max = sorted[len(sorted)-1]
+	d.P50 = percentile(sorted, 0.5)
+	d.P90 = percentile(sorted, 0.9)
+
+	return d
+}
+
+// nearest rank percentile of the sorted samples
+func percentile(sorted []float64, p float64) float64 {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+
+	return sorted[idx]
+}
@@ -0,0 +1,120 @@
+// Package simulation runs headless matches with synthetic players to balance the bloopses and the scoring
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+	"github.com/bloops-games/bloops/internal/bloopsbot/resource"
+	"github.com/bloops-games/bloops/internal/database/matchstate/model"
+	userModel "github.com/bloops-games/bloops/internal/database/user/model"
+)
+
+// pauses of the real match flow around every turn: passing the turn, the letter, ready-set-go and the results
+const (
+	turnOverhead   = 15
+	bloopsOverhead = 3
+)
+
+// Run plays the matches one by one and collects the distributions
+func Run(ctx context.Context, config Config) (Report, error) {
+	if err := config.validate(); err != nil {
+		return Report{}, fmt.Errorf("validate: %w", err)
+	}
+
+	c := newCollector()
+	for i := 0; i < config.Matches; i++ {
+		if err := ctx.Err(); err != nil {
+			return Report{}, err
+		}
+
+		playMatch(config, config.Seed+int64(i), c)
+	}
+
+	return c.report(config), nil
+}
+
+func playMatch(config Config, seed int64, c *collector) {
+	var bloopses []resource.Bloops
+	if config.Bloops {
+		bloopses = append(bloopses, resource.Bloopses...)
+	}
+
+	session := match.NewSession(match.Config{
+		RoundsNum: config.RoundsNum,
+		RoundTime: config.RoundTime,
+		Scoring:   config.Scoring,
+		Bloopses:  bloopses,
+		Seed:      seed,
+	})
+
+	models := make(map[int64]PlayerModel, len(config.Players))
+	for i, p := range config.Players {
+		userID := int64(i + 1)
+		models[userID] = p
+		session.Players = append(
+			session.Players,
+			model.NewPlayer(userID, userModel.User{ID: userID, FirstName: p.Name}, false),
+		)
+	}
+
+	// the players have their own source, so the choices of the match do not depend on the player models
+	rnd := rand.New(rand.NewSource(seed)) // nolint
+	var duration float64
+	for round := 0; round < config.RoundsNum; round++ {
+		session.CurrRoundIdx = round
+		for {
+			var elapsed float64
+			turn, ok := session.SimulateTurn(func(player *model.Player, bloops *resource.Bloops, roundTime int) match.RoundResult {
+				p := models[player.UserID]
+				var result match.RoundResult
+				result, elapsed = p.play(rnd, bloops, roundTime)
+				return result
+			})
+			if !ok {
+				break
+			}
+
+			p := models[turn.Player.UserID]
+			duration += turnOverhead + p.StartDelay + elapsed
+			if turn.Bloops != nil {
+				duration += bloopsOverhead + p.StartDelay
+			}
+
+			c.addTurn(turn)
+		}
+	}
+
+	scores := session.Scores()
+	margin := scores[0].Points
+	if len(scores) > 1 {
+		margin -= scores[1].Points
+	}
+
+	c.addMatch(margin, duration)
+}
+
+// play the turn, returns the result and the seconds the timer was running
+func (p PlayerModel) play(rnd *rand.Rand, bloops *resource.Bloops, roundTime int) (match.RoundResult, float64) {
+	var bloopsName string
+	if bloops != nil {
+		bloopsName = bloops.Name
+	}
+
+	if roundTime <= 0 {
+		return match.RoundResult{}, 0
+	}
+
+	need := math.Max(1, p.ReactionTime+rnd.NormFloat64()*p.ReactionSpread)
+	elapsed := float64(roundTime)
+	var seconds int
+	if rnd.Float64() < p.completion(bloopsName) && need < float64(roundTime) {
+		elapsed = math.Ceil(need)
+		seconds = roundTime - int(elapsed)
+	}
+
+	return match.RoundResult{Seconds: seconds, Words: int(elapsed * p.WordsPerSecond)}, elapsed
+}
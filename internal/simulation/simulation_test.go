@@ -0,0 +1,85 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bloops-games/bloops/internal/bloopsbot/match"
+)
+
+func testConfig(completion float64) Config {
+	player := PlayerModel{StartDelay: 3, ReactionTime: 10, ReactionSpread: 2, Completion: completion}
+	return Config{
+		Matches:   50,
+		RoundsNum: 3,
+		RoundTime: 30,
+		Scoring:   match.ScoringKindSeconds,
+		Bloops:    true,
+		Players:   []PlayerModel{player, player, player},
+		Seed:      7,
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		completion float64
+		points     func(mean float64) bool
+	}{
+		{name: "everyone_fails", completion: 0, points: func(mean float64) bool { return mean == 0 }},
+		{name: "everyone_completes", completion: 1, points: func(mean float64) bool { return mean > 0 }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := Run(context.Background(), testConfig(tc.completion))
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+
+			points, ok := report.Row(MetricPointsPerRound, "")
+			if !ok || points.Count != 50*3*3 {
+				t.Fatalf("unexpected points row %+v", points)
+			}
+
+			if !tc.points(points.Mean) {
+				t.Errorf("unexpected mean points %v", points.Mean)
+			}
+
+			if completion, ok := report.Row(MetricBloopsCompletion, ""); !ok || completion.Mean != tc.completion {
+				t.Errorf("expected bloops completion %v, got %+v", tc.completion, completion)
+			}
+		})
+	}
+}
+
+func TestRunReplay(t *testing.T) {
+	t.Parallel()
+
+	var out [2]bytes.Buffer
+	for i := range out {
+		report, err := Run(context.Background(), testConfig(0.7))
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if err := report.WriteCSV(&out[i]); err != nil {
+			t.Fatalf("write csv: %v", err)
+		}
+	}
+
+	if out[0].String() != out[1].String() {
+		t.Errorf("the same seed gave different reports:\n%s\n%s", out[0].String(), out[1].String())
+	}
+
+	if !strings.HasPrefix(out[0].String(), "metric,name,count") {
+		t.Errorf("unexpected csv header: %s", out[0].String())
+	}
+}
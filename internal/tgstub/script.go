@@ -0,0 +1,132 @@
+package tgstub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const defaultExpectTimeout = 10 * time.Second
+
+// Script the scripted conversation of the virtual users with the bot
+//
+//	{
+//	  "users": [{"id": 1001, "firstName": "Маша", "username": "masha"}],
+//	  "steps": [
+//	    {"user": "masha", "send": "/start"},
+//	    {"user": "masha", "expect": "Привет", "timeout": "5s"},
+//	    {"user": "masha", "click": "Создать"},
+//	    {"sleep": "1s"}
+//	  ]
+//	}
+type Script struct {
+	Users []ScriptUser `json:"users"`
+	Steps []ScriptStep `json:"steps"`
+}
+
+type ScriptUser struct {
+	ID        int    `json:"id"`
+	FirstName string `json:"firstName"`
+	Username  string `json:"username"`
+}
+
+// ScriptStep exactly one of send, click, expect or sleep is performed
+type ScriptStep struct {
+	User    string   `json:"user"`
+	Send    string   `json:"send"`
+	Click   string   `json:"click"`
+	Expect  string   `json:"expect"`
+	Sleep   Duration `json:"sleep"`
+	Timeout Duration `json:"timeout"`
+}
+
+// Duration the duration written as "1s" or "500ms"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("unmarshal duration: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("parse duration: %w", err)
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+func ParseScript(r io.Reader) (Script, error) {
+	var script Script
+	if err := json.NewDecoder(r).Decode(&script); err != nil {
+		return script, fmt.Errorf("decode script: %w", err)
+	}
+
+	return script, nil
+}
+
+// AddUsers registers the virtual users of the script
+func (s *Server) AddUsers(script Script) {
+	for _, u := range script.Users {
+		s.AddUser(u.ID, u.FirstName, u.Username)
+	}
+}
+
+// Play performs the steps one by one, the first failed step stops the script
+func (s *Server) Play(ctx context.Context, script Script) error {
+	for i, step := range script.Steps {
+		if err := s.playStep(ctx, step); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) playStep(ctx context.Context, step ScriptStep) error {
+	if step.Sleep > 0 {
+		timer := time.NewTimer(time.Duration(step.Sleep))
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		return nil
+	}
+
+	u, ok := s.UserByUsername(step.User)
+	if !ok {
+		return fmt.Errorf("%q: %w", step.User, ErrUserNotFound)
+	}
+
+	switch {
+	case step.Send != "":
+		u.Send(step.Send)
+	case step.Click != "":
+		if err := u.Click(step.Click); err != nil {
+			return fmt.Errorf("click: %w", err)
+		}
+	case step.Expect != "":
+		timeout := time.Duration(step.Timeout)
+		if timeout <= 0 {
+			timeout = defaultExpectTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if _, err := u.Expect(ctx, step.Expect); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("empty step")
+	}
+
+	return nil
+}
@@ -0,0 +1,391 @@
+// Package tgstub is an offline stand-in for the subset of the Telegram Bot API used by the bot.
+// Virtual users send messages and click buttons, the bot receives them through getUpdates
+package tgstub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	defaultUpdatesLimit = 100
+	maxPollTimeout      = 50 * time.Second
+	// the uploaded files are kept in memory
+	maxUploadSize = 10 << 20
+)
+
+var ErrUserNotFound = fmt.Errorf("user not found")
+
+// NewServer the stand-in accepting requests for the bot with the token, any token is accepted if empty
+func NewServer(token string) *Server {
+	return &Server{
+		token:   token,
+		bot:     tgbotapi.User{ID: 1, FirstName: "Bloops", UserName: "bloops_stub_bot", IsBot: true},
+		users:   map[int64]*User{},
+		changed: make(chan struct{}),
+	}
+}
+
+type Server struct {
+	token string
+	bot   tgbotapi.User
+
+	mtx           sync.Mutex
+	users         map[int64]*User
+	updates       []tgbotapi.Update
+	lastUpdateID  int
+	lastMessageID int
+	lastQueryID   int
+	// closed and replaced every time an update is queued
+	changed chan struct{}
+}
+
+// AddUser the virtual user chatting with the bot in the private chat
+func (s *Server) AddUser(id int, firstName, username string) *User {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	u := &User{
+		srv:      s,
+		User:     tgbotapi.User{ID: id, FirstName: firstName, UserName: username},
+		messages: map[int]*Message{},
+		changed:  make(chan struct{}),
+	}
+	s.users[int64(id)] = u
+
+	return u
+}
+
+func (s *Server) User(id int64) (*User, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	u, ok := s.users[id]
+	return u, ok
+}
+
+func (s *Server) UserByUsername(username string) (*User, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, u := range s.users {
+		if strings.EqualFold(u.User.UserName, username) {
+			return u, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/bot")
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || path == r.URL.Path {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	token, method := path[:idx], path[idx+1:]
+	if s.token != "" && token != s.token {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	parseForm := r.ParseForm
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		parseForm = func() error {
+			return r.ParseMultipartForm(maxUploadSize)
+		}
+	}
+
+	if err := parseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch method {
+	case "getMe":
+		result = s.bot
+	case "getUpdates":
+		result, err = s.getUpdates(r)
+	case "setWebhook", "deleteWebhook":
+		result = true
+	case "getWebhookInfo":
+		result = tgbotapi.WebhookInfo{}
+	case "sendMessage":
+		result, err = s.sendMessage(r.Form)
+	case "sendSticker":
+		result, err = s.sendSticker(r.Form)
+	case "sendPhoto":
+		result, err = s.sendPhoto(r)
+	case "editMessageText":
+		result, err = s.editMessage(r.Form, true)
+	case "editMessageReplyMarkup":
+		result, err = s.editMessage(r.Form, false)
+	case "deleteMessage":
+		result, err = s.deleteMessage(r.Form)
+	case "pinChatMessage":
+		result, err = s.pinMessage(r.Form, true)
+	case "unpinChatMessage":
+		result, err = s.pinMessage(r.Form, false)
+	case "answerCallbackQuery":
+		result = true
+	default:
+		writeError(w, http.StatusNotFound, "Not Found: method not found")
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, tgbotapi.APIResponse{Ok: true, Result: raw})
+}
+
+// long polling, the request waits for the first update or the timeout
+func (s *Server) getUpdates(r *http.Request) ([]tgbotapi.Update, error) {
+	offset, _ := strconv.Atoi(r.Form.Get("offset"))
+	limit, _ := strconv.Atoi(r.Form.Get("limit"))
+	if limit <= 0 || limit > defaultUpdatesLimit {
+		limit = defaultUpdatesLimit
+	}
+
+	timeout, _ := strconv.Atoi(r.Form.Get("timeout"))
+	wait := time.Duration(timeout) * time.Second
+	if wait > maxPollTimeout {
+		wait = maxPollTimeout
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		s.mtx.Lock()
+		// updates before the offset are confirmed by the bot
+		n := 0
+		for n < len(s.updates) && s.updates[n].UpdateID < offset {
+			n++
+		}
+		s.updates = s.updates[n:]
+
+		if len(s.updates) > 0 || wait == 0 {
+			if limit > len(s.updates) {
+				limit = len(s.updates)
+			}
+			updates := append([]tgbotapi.Update{}, s.updates[:limit]...)
+			s.mtx.Unlock()
+			return updates, nil
+		}
+
+		changed := s.changed
+		s.mtx.Unlock()
+
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-timer.C:
+			return []tgbotapi.Update{}, nil
+		case <-changed:
+		}
+	}
+}
+
+func (s *Server) sendMessage(form formValues) (tgbotapi.Message, error) {
+	u, err := s.formUser(form)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	text := form.Get("text")
+	if text == "" {
+		return tgbotapi.Message{}, fmt.Errorf("message text is empty")
+	}
+
+	markup, err := parseReplyMarkup(form.Get("reply_markup"))
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	msg := &Message{ID: s.nextMessageID(), Text: text, ParseMode: form.Get("parse_mode")}
+	msg.applyMarkup(markup)
+
+	return s.botMessage(u, u.receive(msg)), nil
+}
+
+func (s *Server) sendSticker(form formValues) (tgbotapi.Message, error) {
+	u, err := s.formUser(form)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	msg := &Message{ID: s.nextMessageID(), Sticker: form.Get("sticker")}
+
+	return s.botMessage(u, u.receive(msg)), nil
+}
+
+// sendPhoto the uploaded photo is kept as is
+func (s *Server) sendPhoto(r *http.Request) (tgbotapi.Message, error) {
+	u, err := s.formUser(r.Form)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	f, _, err := r.FormFile("photo")
+	if err != nil {
+		return tgbotapi.Message{}, fmt.Errorf("photo file: %w", err)
+	}
+
+	defer f.Close()
+
+	photo, err := io.ReadAll(f)
+	if err != nil {
+		return tgbotapi.Message{}, fmt.Errorf("read photo: %w", err)
+	}
+
+	msg := &Message{ID: s.nextMessageID(), Photo: photo}
+
+	return s.botMessage(u, u.receive(msg)), nil
+}
+
+func (s *Server) editMessage(form formValues, text bool) (tgbotapi.Message, error) {
+	u, err := s.formUser(form)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	messageID, _ := strconv.Atoi(form.Get("message_id"))
+	markup, err := parseReplyMarkup(form.Get("reply_markup"))
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	msg, err := u.edit(messageID, func(msg *Message) {
+		if text {
+			msg.Text = form.Get("text")
+			msg.ParseMode = form.Get("parse_mode")
+		}
+		// an edit without the markup removes the inline keyboard
+		msg.Inline = nil
+		msg.applyMarkup(markup)
+	})
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	return s.botMessage(u, msg), nil
+}
+
+func (s *Server) deleteMessage(form formValues) (bool, error) {
+	u, err := s.formUser(form)
+	if err != nil {
+		return false, err
+	}
+
+	messageID, _ := strconv.Atoi(form.Get("message_id"))
+	if err := u.delete(messageID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Server) pinMessage(form formValues, pin bool) (bool, error) {
+	u, err := s.formUser(form)
+	if err != nil {
+		return false, err
+	}
+
+	var messageID int
+	if pin {
+		messageID, _ = strconv.Atoi(form.Get("message_id"))
+	}
+
+	if err := u.pin(messageID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// in the private chat the chat id is the id of the user
+func (s *Server) formUser(form formValues) (*User, error) {
+	chatID, err := strconv.ParseInt(form.Get("chat_id"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("chat_id: %w", err)
+	}
+
+	u, ok := s.User(chatID)
+	if !ok {
+		return nil, fmt.Errorf("chat %d: %w", chatID, ErrUserNotFound)
+	}
+
+	return u, nil
+}
+
+func (s *Server) botMessage(u *User, msg Message) tgbotapi.Message {
+	return tgbotapi.Message{
+		MessageID: msg.ID,
+		From:      &s.bot,
+		Date:      int(msg.Date.Unix()),
+		Chat:      u.chat(),
+		Text:      msg.Text,
+	}
+}
+
+func (s *Server) nextMessageID() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.lastMessageID++
+	return s.lastMessageID
+}
+
+func (s *Server) nextQueryID() string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.lastQueryID++
+	return strconv.Itoa(s.lastQueryID)
+}
+
+func (s *Server) pushUpdate(update tgbotapi.Update) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.lastUpdateID++
+	update.UpdateID = s.lastUpdateID
+	s.updates = append(s.updates, update)
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+type formValues interface {
+	Get(key string) string
+}
+
+func writeError(w http.ResponseWriter, code int, description string) {
+	writeResponse(w, code, tgbotapi.APIResponse{ErrorCode: code, Description: description})
+}
+
+func writeResponse(w http.ResponseWriter, code int, resp tgbotapi.APIResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
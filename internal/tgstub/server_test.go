@@ -0,0 +1,110 @@
+package tgstub
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/httputil"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+func TestServerConversation(t *testing.T) {
+	t.Parallel()
+
+	stub := NewServer("token")
+	masha := stub.AddUser(1001, "Маша", "masha")
+
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	endpoint, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	tg, err := tgbotapi.NewBotAPIWithClient(
+		"token",
+		httputil.NewClient(httputil.NewEndpointRoundTripper(endpoint, http.DefaultTransport)),
+	)
+	if err != nil {
+		t.Fatalf("new bot api: %v", err)
+	}
+
+	masha.Send("/start")
+	updates, err := tg.GetUpdates(tgbotapi.UpdateConfig{Timeout: 1})
+	if err != nil {
+		t.Fatalf("get updates: %v", err)
+	}
+
+	if len(updates) != 1 || updates[0].Message.Text != "/start" || updates[0].Message.Chat.ID != 1001 {
+		t.Fatalf("unexpected updates %+v", updates)
+	}
+
+	msg := tgbotapi.NewMessage(1001, "Привет")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Играть", "play"),
+	))
+	sent, err := tg.Send(msg)
+	if err != nil {
+		t.Fatalf("send msg: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := masha.Expect(ctx, "Привет"); err != nil {
+		t.Fatalf("expect: %v", err)
+	}
+
+	if err := masha.Click("Играть"); err != nil {
+		t.Fatalf("click: %v", err)
+	}
+
+	updates, err = tg.GetUpdates(tgbotapi.UpdateConfig{Offset: updates[0].UpdateID + 1, Timeout: 1})
+	if err != nil {
+		t.Fatalf("get updates: %v", err)
+	}
+
+	if len(updates) != 1 || updates[0].CallbackQuery == nil || updates[0].CallbackQuery.Data != "play" {
+		t.Fatalf("unexpected updates %+v", updates)
+	}
+
+	if _, err := tg.Send(tgbotapi.NewEditMessageText(1001, sent.MessageID, "Поехали")); err != nil {
+		t.Fatalf("edit msg: %v", err)
+	}
+
+	edited, err := masha.Expect(ctx, "Поехали")
+	if err != nil {
+		t.Fatalf("expect: %v", err)
+	}
+
+	if !edited.Edited || len(edited.Inline) != 0 {
+		t.Errorf("expected edited message without keyboard, got %+v", edited)
+	}
+
+	pin := tgbotapi.PinChatMessageConfig{ChatID: 1001, MessageID: sent.MessageID, DisableNotification: true}
+	if _, err := tg.PinChatMessage(pin); err != nil {
+		t.Fatalf("pin msg: %v", err)
+	}
+
+	if messages := masha.Messages(); !messages[len(messages)-1].Pinned {
+		t.Errorf("expected pinned message")
+	}
+
+	photo := []byte("\x89PNG")
+	if _, err := tg.Send(tgbotapi.NewPhotoUpload(1001, tgbotapi.FileBytes{Name: "card.png", Bytes: photo})); err != nil {
+		t.Fatalf("send photo: %v", err)
+	}
+
+	messages := masha.Messages()
+	if last := messages[len(messages)-1]; string(last.Photo) != string(photo) {
+		t.Errorf("expected uploaded photo, got %+v", last)
+	}
+
+	if _, err := tg.Send(tgbotapi.NewMessage(42, "Кто здесь?")); err == nil {
+		t.Errorf("expected error for unknown chat")
+	}
+}
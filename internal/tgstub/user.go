@@ -0,0 +1,281 @@
+package tgstub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+var (
+	ErrMessageNotFound = fmt.Errorf("message not found")
+	ErrButtonNotFound  = fmt.Errorf("button not found")
+)
+
+// Message the message of the bot as the virtual user sees it
+type Message struct {
+	ID        int
+	Date      time.Time
+	Text      string
+	ParseMode string
+	Sticker   string
+	// the uploaded photo, png for the result cards
+	Photo []byte
+	// inline keyboard attached to the message
+	Inline [][]tgbotapi.InlineKeyboardButton
+	// reply keyboard shown instead of the user's keyboard
+	Keyboard [][]tgbotapi.KeyboardButton
+	Edited   bool
+	Deleted  bool
+	Pinned   bool
+}
+
+// button the inline button with the text, the last matching button wins
+func (m Message) button(text string) (tgbotapi.InlineKeyboardButton, bool) {
+	for _, row := range m.Inline {
+		for _, btn := range row {
+			if btn.CallbackData != nil && strings.Contains(btn.Text, text) {
+				return btn, true
+			}
+		}
+	}
+
+	return tgbotapi.InlineKeyboardButton{}, false
+}
+
+func (m *Message) applyMarkup(markup replyMarkup) {
+	if markup.InlineKeyboard != nil {
+		m.Inline = markup.InlineKeyboard
+	}
+
+	if markup.Keyboard != nil {
+		m.Keyboard = markup.Keyboard
+	}
+
+	if markup.RemoveKeyboard {
+		m.Keyboard = [][]tgbotapi.KeyboardButton{}
+	}
+}
+
+type replyMarkup struct {
+	Keyboard       [][]tgbotapi.KeyboardButton       `json:"keyboard"`
+	InlineKeyboard [][]tgbotapi.InlineKeyboardButton `json:"inline_keyboard"`
+	RemoveKeyboard bool                              `json:"remove_keyboard"`
+}
+
+func parseReplyMarkup(raw string) (replyMarkup, error) {
+	var markup replyMarkup
+	if raw == "" {
+		return markup, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &markup); err != nil {
+		return markup, fmt.Errorf("reply_markup: %w", err)
+	}
+
+	return markup, nil
+}
+
+// User the virtual user, everything the bot sends is kept as the chat history
+type User struct {
+	srv  *Server
+	User tgbotapi.User
+
+	mtx      sync.Mutex
+	messages map[int]*Message
+	// ids of the new and edited messages in the order of arrival
+	events []int
+	cursor int
+	// the reply keyboard currently shown to the user
+	keyboard [][]tgbotapi.KeyboardButton
+	changed  chan struct{}
+}
+
+// Send the text message to the bot, pressing the reply keyboard button sends its text too
+func (u *User) Send(text string) {
+	u.srv.pushUpdate(tgbotapi.Update{
+		Message: &tgbotapi.Message{
+			MessageID: u.srv.nextMessageID(),
+			From:      &u.User,
+			Date:      int(time.Now().Unix()),
+			Chat:      u.chat(),
+			Text:      text,
+		},
+	})
+}
+
+// Click the inline button containing the text on the latest message having it,
+// falls back to the reply keyboard button
+func (u *User) Click(text string) error {
+	u.mtx.Lock()
+	ids := make([]int, 0, len(u.messages))
+	for id := range u.messages {
+		ids = append(ids, id)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ids)))
+
+	for _, id := range ids {
+		msg := u.messages[id]
+		if msg.Deleted {
+			continue
+		}
+
+		if btn, ok := msg.button(text); ok {
+			u.mtx.Unlock()
+			u.srv.pushUpdate(tgbotapi.Update{
+				CallbackQuery: &tgbotapi.CallbackQuery{
+					ID:   u.srv.nextQueryID(),
+					From: &u.User,
+					Message: &tgbotapi.Message{
+						MessageID: msg.ID,
+						From:      &u.srv.bot,
+						Chat:      u.chat(),
+						Text:      msg.Text,
+					},
+					Data: *btn.CallbackData,
+				},
+			})
+
+			return nil
+		}
+	}
+
+	for _, row := range u.keyboard {
+		for _, btn := range row {
+			if strings.Contains(btn.Text, text) {
+				u.mtx.Unlock()
+				u.Send(btn.Text)
+				return nil
+			}
+		}
+	}
+	u.mtx.Unlock()
+
+	return fmt.Errorf("%q: %w", text, ErrButtonNotFound)
+}
+
+// Expect waits for the new or edited message containing the text, the messages seen before are skipped
+func (u *User) Expect(ctx context.Context, text string) (Message, error) {
+	for {
+		u.mtx.Lock()
+		for u.cursor < len(u.events) {
+			msg := u.messages[u.events[u.cursor]]
+			u.cursor++
+			if strings.Contains(msg.Text, text) || (msg.Sticker != "" && msg.Sticker == text) {
+				found := *msg
+				u.mtx.Unlock()
+				return found, nil
+			}
+		}
+
+		changed := u.changed
+		u.mtx.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return Message{}, fmt.Errorf("expect %q: %w", text, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Messages the chat history without the deleted messages
+func (u *User) Messages() []Message {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	messages := make([]Message, 0, len(u.messages))
+	for _, msg := range u.messages {
+		if !msg.Deleted {
+			messages = append(messages, *msg)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	return messages
+}
+
+func (u *User) receive(msg *Message) Message {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	msg.Date = time.Now()
+	u.messages[msg.ID] = msg
+	if msg.Keyboard != nil {
+		u.keyboard = msg.Keyboard
+	}
+	u.publish(msg.ID)
+
+	return *msg
+}
+
+func (u *User) edit(messageID int, fn func(msg *Message)) (Message, error) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	msg, ok := u.messages[messageID]
+	if !ok || msg.Deleted {
+		return Message{}, fmt.Errorf("message to edit %d: %w", messageID, ErrMessageNotFound)
+	}
+
+	fn(msg)
+	msg.Edited = true
+	u.publish(msg.ID)
+
+	return *msg, nil
+}
+
+func (u *User) delete(messageID int) error {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	msg, ok := u.messages[messageID]
+	if !ok || msg.Deleted {
+		return fmt.Errorf("message to delete %d: %w", messageID, ErrMessageNotFound)
+	}
+
+	msg.Deleted = true
+
+	return nil
+}
+
+// pin only one message is pinned in the private chat, the unpin without the message removes the pin
+func (u *User) pin(messageID int) error {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	if messageID != 0 {
+		if msg, ok := u.messages[messageID]; !ok || msg.Deleted {
+			return fmt.Errorf("message to pin %d: %w", messageID, ErrMessageNotFound)
+		}
+	}
+
+	for id, msg := range u.messages {
+		msg.Pinned = id == messageID
+	}
+
+	return nil
+}
+
+func (u *User) publish(messageID int) {
+	u.events = append(u.events, messageID)
+	close(u.changed)
+	u.changed = make(chan struct{})
+}
+
+func (u *User) chat() *tgbotapi.Chat {
+	return &tgbotapi.Chat{
+		ID:        int64(u.User.ID),
+		Type:      "private",
+		UserName:  u.User.UserName,
+		FirstName: u.User.FirstName,
+	}
+}
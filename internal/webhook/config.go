@@ -0,0 +1,15 @@
+package webhook
+
+import "time"
+
+type Config struct {
+	// Receiver of the match events, webhooks are disabled if empty
+	URL string `envconfig:"BLOOP_EVENT_WEBHOOK_URL"`
+	// Key of the HMAC-SHA256 signature sent in the X-Bloops-Signature header
+	Secret string `envconfig:"BLOOP_EVENT_WEBHOOK_SECRET"`
+	// The delivery is dropped after this number of failed attempts
+	MaxAttempts int `envconfig:"BLOOP_EVENT_WEBHOOK_MAX_ATTEMPTS" default:"8"`
+	// Delay before the first retry, doubled after every failed attempt
+	Backoff time.Duration `envconfig:"BLOOP_EVENT_WEBHOOK_BACKOFF" default:"2s"`
+	Timeout time.Duration `envconfig:"BLOOP_EVENT_WEBHOOK_TIMEOUT" default:"10s"`
+}
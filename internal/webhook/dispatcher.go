@@ -0,0 +1,204 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	webhookDb "github.com/bloops-games/bloops/internal/database/webhook/database"
+	"github.com/bloops-games/bloops/internal/database/webhook/model"
+	"github.com/bloops-games/bloops/internal/logging"
+)
+
+const (
+	maxBackoff   = 10 * time.Minute
+	idleInterval = time.Minute
+)
+
+func NewDispatcher(config Config, db *webhookDb.DB) *Dispatcher {
+	return &Dispatcher{
+		config:   config,
+		db:       db,
+		client:   &http.Client{Timeout: config.Timeout},
+		notifyCh: make(chan struct{}, 1),
+	}
+}
+
+// Dispatcher delivers the events one by one in the order they were emitted,
+// the failed delivery holds back the later ones until it is sent or dropped.
+// Events are persisted first, so the deliveries survive the restart
+type Dispatcher struct {
+	config Config
+	db     *webhookDb.DB
+	client *http.Client
+
+	notifyCh chan struct{}
+}
+
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && d.config.URL != ""
+}
+
+// Emit puts the event into the delivery queue
+func (d *Dispatcher) Emit(typ EventType, data interface{}) error {
+	if !d.Enabled() {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if _, err := d.db.Add(model.NewDelivery(string(typ), payload)); err != nil {
+		return fmt.Errorf("webhook db add: %w", err)
+	}
+
+	select {
+	case d.notifyCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	if !d.Enabled() {
+		return
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.notifyCh:
+		case <-timer.C:
+		}
+
+		wait := d.flush(ctx)
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// flush sends the due deliveries and returns the time to wait until the next one is due
+func (d *Dispatcher) flush(ctx context.Context) time.Duration {
+	logger := logging.FromContext(ctx).Named("webhook.flush")
+
+	deliveries, err := d.db.FetchAll()
+	if err != nil {
+		logger.Errorf("webhook db fetch all: %v", err)
+		return idleInterval
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return idleInterval
+		}
+
+		// the later events wait for the pending one, so the receiver gets them in order
+		if until := time.Until(delivery.NextAttemptAt); until > 0 {
+			return until
+		}
+
+		err := d.deliver(ctx, delivery)
+		if err == nil {
+			if err := d.db.Delete(delivery.ID); err != nil {
+				logger.Errorf("webhook db delete: %v", err)
+			}
+			continue
+		}
+
+		delivery.Attempts++
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= d.config.MaxAttempts {
+			logger.Errorf("webhook %s %d dropped after %d attempts: %v", delivery.Event, delivery.ID, delivery.Attempts, err)
+			if err := d.db.Delete(delivery.ID); err != nil {
+				logger.Errorf("webhook db delete: %v", err)
+			}
+			continue
+		}
+
+		backoff := d.backoff(delivery.Attempts)
+		delivery.NextAttemptAt = time.Now().Add(backoff)
+		if err := d.db.Store(delivery); err != nil {
+			logger.Errorf("webhook db store: %v", err)
+		}
+
+		return backoff
+	}
+
+	return idleInterval
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.config.Backoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	return backoff
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery model.Delivery) error {
+	body, err := json.Marshal(Event{
+		ID:        delivery.ID,
+		Type:      EventType(delivery.Event),
+		CreatedAt: delivery.CreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Bloops-Event", delivery.Event)
+	req.Header.Set("X-Bloops-Delivery", strconv.FormatUint(delivery.ID, 10))
+	if d.config.Secret != "" {
+		req.Header.Set("X-Bloops-Signature", Sign([]byte(d.config.Secret), body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign the value of the X-Bloops-Signature header, the receiver computes the same HMAC over the raw body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloops-games/bloops/internal/database"
+	webhookDb "github.com/bloops-games/bloops/internal/database/webhook/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestDispatcherRetry(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	var mtx sync.Mutex
+	var calls int
+	received := make(chan Event, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		calls++
+		n := calls
+		mtx.Unlock()
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+
+		if got := r.Header.Get("X-Bloops-Signature"); got != Sign(secret, body) {
+			t.Errorf("unexpected signature %s", got)
+		}
+
+		// the receiver is not ready on the first attempt
+		if n == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("unmarshal: %v", err)
+		}
+
+		received <- event
+	}))
+	defer srv.Close()
+
+	bdb, err := bolt.Open(filepath.Join(t.TempDir(), "db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer bdb.Close()
+
+	db := webhookDb.New(&database.DB{DB: bdb})
+	d := NewDispatcher(Config{
+		URL:         srv.URL,
+		Secret:      string(secret),
+		MaxAttempts: 3,
+		Backoff:     10 * time.Millisecond,
+		Timeout:     time.Second,
+	}, db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	if err := d.Emit(EventLobbyCreated, Lobby{Code: 1234}); err != nil {
+		t.Fatalf("emit: %v", err)
+	}
+
+	select {
+	case event := <-received:
+		if event.Type != EventLobbyCreated {
+			t.Errorf("expected %s, got %s", EventLobbyCreated, event.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("webhook was not delivered")
+	}
+
+	// the delivery is removed from the queue asynchronously after the response
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		deliveries, err := db.FetchAll()
+		if err != nil {
+			t.Fatalf("fetch all: %v", err)
+		}
+
+		if len(deliveries) == 0 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected empty queue, got %d deliveries", len(deliveries))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDispatcherOrder(t *testing.T) {
+	t.Parallel()
+
+	var mtx sync.Mutex
+	var calls int
+	received := make(chan EventType, 3)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		calls++
+		n := calls
+		mtx.Unlock()
+
+		// the first event fails once, the second one must wait for it
+		if n == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		received <- EventType(r.Header.Get("X-Bloops-Event"))
+	}))
+	defer srv.Close()
+
+	bdb, err := bolt.Open(filepath.Join(t.TempDir(), "db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer bdb.Close()
+
+	d := NewDispatcher(Config{
+		URL:         srv.URL,
+		MaxAttempts: 3,
+		Backoff:     50 * time.Millisecond,
+		Timeout:     time.Second,
+	}, webhookDb.New(&database.DB{DB: bdb}))
+
+	if err := d.Emit(EventPlayerJoined, Player{}); err != nil {
+		t.Fatalf("emit: %v", err)
+	}
+
+	if err := d.Emit(EventMatchFinished, MatchResult{Code: 1234}); err != nil {
+		t.Fatalf("emit: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	for _, expected := range []EventType{EventPlayerJoined, EventMatchFinished} {
+		select {
+		case typ := <-received:
+			if typ != expected {
+				t.Fatalf("expected %s, got %s", expected, typ)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s was not delivered", expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import "time"
+
+type EventType string
+
+const (
+	EventLobbyCreated  EventType = "lobby.created"
+	EventPlayerJoined  EventType = "player.joined"
+	EventMatchStarted  EventType = "match.started"
+	EventRoundClosed   EventType = "round.closed"
+	EventMatchFinished EventType = "match.finished"
+)
+
+// Event the body of the webhook request
+type Event struct {
+	ID        uint64      `json:"id"`
+	Type      EventType   `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+type Lobby struct {
+	Code       int64    `json:"code"`
+	AuthorID   int64    `json:"authorId"`
+	AuthorName string   `json:"authorName"`
+	RoundsNum  int      `json:"roundsNum"`
+	RoundTime  int      `json:"roundTime"`
+	Categories []string `json:"categories"`
+}
+
+type Player struct {
+	Code      int64  `json:"code"`
+	UserID    int64  `json:"userId"`
+	FirstName string `json:"firstName"`
+	Offline   bool   `json:"offline"`
+}
+
+type Match struct {
+	Code    int64    `json:"code"`
+	Players []Player `json:"players"`
+}
+
+type Round struct {
+	Code   int64   `json:"code"`
+	Round  int     `json:"round"`
+	Scores []Score `json:"scores"`
+}
+
+type Score struct {
+	UserID    int64  `json:"userId"`
+	FirstName string `json:"firstName"`
+	Points    int    `json:"points"`
+	Place     int    `json:"place"`
+}
+
+type MatchResult struct {
+	Code   int64   `json:"code"`
+	Rounds int     `json:"rounds"`
+	Scores []Score `json:"scores"`
+}
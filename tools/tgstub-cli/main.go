@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/bloops-games/bloops/internal/logging"
+	"github.com/bloops-games/bloops/internal/server"
+	"github.com/bloops-games/bloops/internal/shutdown"
+	"github.com/bloops-games/bloops/internal/tgstub"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Offline stand-in for the Telegram Bot API, run the bot with BLOOP_BOT_API_ENDPOINT=http://localhost:8081
+// and the same BLOOP_BOT_TOKEN to play the script of the virtual users against it
+type Config struct {
+	Port  string `envconfig:"BLOOP_TGSTUB_PORT" default:"8081"`
+	Token string `envconfig:"BLOOP_TGSTUB_TOKEN"`
+	// json file with the virtual users and the steps, see tgstub.Script
+	Script string `envconfig:"BLOOP_TGSTUB_SCRIPT"`
+}
+
+func main() {
+	flag.Parse()
+	ctx, cancel := shutdown.New()
+	logger := logging.FromContext(ctx)
+	defer cancel()
+	config := Config{}
+	if err := envconfig.Process("", &config); err != nil {
+		logger.Fatalf("processing the config: %v", err)
+	}
+
+	stub := tgstub.NewServer(config.Token)
+
+	var script tgstub.Script
+	if config.Script != "" {
+		f, err := os.Open(config.Script)
+		if err != nil {
+			logger.Fatalf("open script: %v", err)
+		}
+
+		script, err = tgstub.ParseScript(f)
+		_ = f.Close()
+		if err != nil {
+			logger.Fatalf("parse script: %v", err)
+		}
+
+		stub.AddUsers(script)
+	}
+
+	srv, err := server.New(config.Port)
+	if err != nil {
+		logger.Fatalf("server.New: %v", err)
+	}
+
+	go func() {
+		if err := srv.ServeHTTP(ctx, &http.Server{Handler: stub}); err != nil {
+			logger.Errorf("srv.ServeHTTP: %v", err)
+			cancel()
+		}
+	}()
+
+	logger.Infof("Bot API stand-in is listening on port %s", config.Port)
+
+	if len(script.Steps) > 0 {
+		if err := stub.Play(ctx, script); err != nil {
+			logger.Fatalf("play script: %v", err)
+		}
+
+		logger.Infof("Script finished, %d steps played", len(script.Steps))
+	}
+
+	<-ctx.Done()
+}